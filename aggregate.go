@@ -0,0 +1,239 @@
+package tracker
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// SetLabel sets a label of the track (tenant, route, region...),
+// aggregators can break their stats down by label keys
+func (t *Track) SetLabel(key, value string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.labels == nil {
+		t.labels = make(map[string]string)
+	}
+	t.labels[key] = value
+}
+
+// Labels returns a copy of the labels of the track
+func (t *Track) Labels() map[string]string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return copyLabels(t.labels)
+}
+
+func copyLabels(labels map[string]string) map[string]string {
+	if labels == nil {
+		return nil
+	}
+	c := make(map[string]string, len(labels))
+	for k, v := range labels {
+		c[k] = v
+	}
+	return c
+}
+
+// AggStat is the aggregate of the checkpoints sharing a name
+// and the values of the aggregation dimensions
+type AggStat struct {
+	Name string `json:"name"`
+	// Dims holds the values of the dimensions in the aggregator order
+	Dims   []string      `json:"dims,omitempty"`
+	Count  int64         `json:"count"`
+	Errors int64         `json:"errors"`
+	Total  time.Duration `json:"total"`
+	Min    time.Duration `json:"min"`
+	Max    time.Duration `json:"max"`
+}
+
+// Mean returns the mean duration of the checkpoints
+func (s AggStat) Mean() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Count)
+}
+
+// Aggregator collects the checkpoints of many tracks into stats by
+// checkpoint name and, optionally, by the values of label keys declared
+// as dimensions, e.g. NewAggregator("tenant", "route"). It evaluates
+// the alerting rules on every added track. It is safe for concurrent use.
+type Aggregator struct {
+	mu        sync.Mutex
+	dims      []string
+	stats     map[string]*AggStat
+	rules     []*ruleState
+	notifiers []Notifier
+}
+
+func NewAggregator(dimensions ...string) *Aggregator {
+	return &Aggregator{dims: dimensions, stats: make(map[string]*AggStat)}
+}
+
+// Add aggregates the checkpoints of the track broken down by its labels
+func (a *Aggregator) Add(t *Track) {
+	a.AddData(t.Labels(), t.snapshot())
+}
+
+// AddData aggregates the steps of data, labels give the values of the
+// dimensions, a missing label is aggregated as an empty value
+func (a *Aggregator) AddData(labels map[string]string, data MetaData) {
+	if len(data) == 0 {
+		return
+	}
+
+	dims := make([]string, len(a.dims))
+	for i, d := range a.dims {
+		dims[i] = labels[d]
+	}
+
+	a.mu.Lock()
+	for _, m := range steps(data) {
+		key := m.Name + "\x00" + strings.Join(dims, "\x00")
+		s, ok := a.stats[key]
+		if !ok {
+			s = &AggStat{Name: m.Name, Dims: dims, Min: m.Dur}
+			a.stats[key] = s
+		}
+		s.Count++
+		s.Total += m.Dur
+		if m.Dur < s.Min {
+			s.Min = m.Dur
+		}
+		if m.Dur > s.Max {
+			s.Max = m.Dur
+		}
+		if m.Err != nil {
+			s.Errors++
+		}
+	}
+	alerts := a.evaluate(data, time.Now())
+	notifiers := a.notifiers
+	a.mu.Unlock()
+
+	notify(notifiers, alerts)
+}
+
+// Dimensions returns the label keys the stats are broken down by
+func (a *Aggregator) Dimensions() []string {
+	return append([]string(nil), a.dims...)
+}
+
+// Stats returns a copy of the stats ordered by name and dimension values
+func (a *Aggregator) Stats() []AggStat {
+	a.mu.Lock()
+	stats := make([]AggStat, 0, len(a.stats))
+	for _, s := range a.stats {
+		stats = append(stats, *s)
+	}
+	a.mu.Unlock()
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Name != stats[j].Name {
+			return stats[i].Name < stats[j].Name
+		}
+		return strings.Join(stats[i].Dims, "\x00") < strings.Join(stats[j].Dims, "\x00")
+	})
+	return stats
+}
+
+// RenderAggregate renders the stats of the aggregator as a table
+// with a column per dimension
+func (tbr TableRender) RenderAggregate(a *Aggregator) {
+	headers := append([]string{"func.name"}, a.Dimensions()...)
+	headers = append(headers, "count", "mean", "min", "max", "errors")
+
+	out := capture(tbr.Out)
+	table := tablewriter.NewWriter(out)
+	table.SetHeader(headers)
+
+	for _, s := range a.Stats() {
+		row := append([]string{s.Name}, s.Dims...)
+		row = append(row,
+			tbr.count(s.Count),
+			s.Mean().String(),
+			s.Min.String(),
+			s.Max.String(),
+			tbr.count(s.Errors),
+		)
+		table.Append(row)
+	}
+
+	table.Render()
+	if err := out.Err(); err != nil {
+		WriteFailed(tbr.Out, err, "writing data")
+	}
+}
+
+// WritePrometheus writes the stats in the Prometheus text exposition
+// format, the dimensions become labels next to the checkpoint name
+func (a *Aggregator) WritePrometheus(w io.Writer) error {
+	stats := a.Stats()
+
+	var b strings.Builder
+	b.WriteString("# HELP tracker_checkpoint_duration_seconds Duration of tracked checkpoints.\n")
+	b.WriteString("# TYPE tracker_checkpoint_duration_seconds summary\n")
+	for _, s := range stats {
+		labels := a.promLabels(s)
+		fmt.Fprintf(&b, "tracker_checkpoint_duration_seconds_sum{%s} %g\n", labels, s.Total.Seconds())
+		fmt.Fprintf(&b, "tracker_checkpoint_duration_seconds_count{%s} %d\n", labels, s.Count)
+	}
+	b.WriteString("# HELP tracker_checkpoint_duration_max_seconds Longest duration of tracked checkpoints.\n")
+	b.WriteString("# TYPE tracker_checkpoint_duration_max_seconds gauge\n")
+	for _, s := range stats {
+		fmt.Fprintf(&b, "tracker_checkpoint_duration_max_seconds{%s} %g\n", a.promLabels(s), s.Max.Seconds())
+	}
+	b.WriteString("# HELP tracker_checkpoint_errors_total Tracked checkpoints with errors.\n")
+	b.WriteString("# TYPE tracker_checkpoint_errors_total counter\n")
+	for _, s := range stats {
+		fmt.Fprintf(&b, "tracker_checkpoint_errors_total{%s} %d\n", a.promLabels(s), s.Errors)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (a *Aggregator) promLabels(s AggStat) string {
+	var b strings.Builder
+	b.WriteString(`name="`)
+	b.WriteString(promEscaper.Replace(s.Name))
+	b.WriteString(`"`)
+	for i, d := range a.dims {
+		b.WriteString(",")
+		b.WriteString(promName(d))
+		b.WriteString(`="`)
+		b.WriteString(promEscaper.Replace(s.Dims[i]))
+		b.WriteString(`"`)
+	}
+	return b.String()
+}
+
+var promEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// promName makes a valid Prometheus label name of the dimension
+func promName(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, s)
+}
+
+// Handler returns a http.Handler serving the stats for Prometheus scraping
+func (a *Aggregator) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		if err := a.WritePrometheus(w); err != nil {
+			WriteFailed(w, err, "writing metrics")
+		}
+	})
+}
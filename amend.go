@@ -0,0 +1,39 @@
+package tracker
+
+// Amend edits the i-th checkpoint of t with fn, e.g. to attach late
+// information like an error found at the end of a batch before the
+// report is rendered or exported. It returns false if there is no such
+// checkpoint. fn runs with t locked and must not call the methods of t,
+// the Seq of the checkpoint is kept.
+func (t *Track) Amend(i int, fn func(*Meta)) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if i < 0 || i >= t.len() {
+		return false
+	}
+	amend(t.at(i), fn)
+	return true
+}
+
+// AmendName works as Amend on every checkpoint with the name,
+// it returns the number of the amended checkpoints
+func (t *Track) AmendName(name string, fn func(*Meta)) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var n int
+	for i := 0; i < t.len(); i++ {
+		if m := t.at(i); m.Name == name {
+			amend(m, fn)
+			n++
+		}
+	}
+	return n
+}
+
+func amend(m *Meta, fn func(*Meta)) {
+	seq := m.Seq
+	fn(m)
+	m.Seq = seq
+}
@@ -0,0 +1,36 @@
+package tracker
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAmend(t *testing.T) {
+	tr, _ := simTrack(t)
+	s := tr.Step("resolve")
+	s.Done()
+	tr.SetChunkSize(2)
+	s = tr.Step("resolve")
+	s.Done()
+
+	errBatch := errors.New("batch failed")
+	if !tr.Amend(1, func(m *Meta) { m.Err = errBatch; m.Seq = 42 }) {
+		t.Fatal("Amend of an existing checkpoint failed")
+	}
+	if tr.Amend(3, func(*Meta) {}) || tr.Amend(-1, func(*Meta) {}) {
+		t.Error("Amend out of range succeeded")
+	}
+	if m, _ := tr.Get(1); m.Err != errBatch || m.Seq != 1 {
+		t.Errorf("got error %v seq %d, want the error and the seq kept", m.Err, m.Seq)
+	}
+
+	n := tr.AmendName("resolve", func(m *Meta) { m.Attrs = map[string]string{"host": "10.0.0.1"} })
+	if n != 2 {
+		t.Fatalf("amended %d checkpoints, want 2", n)
+	}
+	for _, m := range tr.Snapshot()[1:] {
+		if m.Attrs["host"] != "10.0.0.1" {
+			t.Errorf("checkpoint %d not amended: %+v", m.Seq, m)
+		}
+	}
+}
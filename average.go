@@ -0,0 +1,88 @@
+package tracker
+
+import (
+	"sort"
+	"time"
+)
+
+// Average is the exponential moving average of the checkpoints
+// sharing a name, see SetAverages()
+type Average struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+	// Dur is the moving average duration
+	Dur time.Duration `json:"duration_ns"`
+	// ErrorRate is the moving share of checkpoints with errors
+	ErrorRate float64 `json:"error_rate"`
+}
+
+// averages holds the moving averages of a track by checkpoint name
+type averages struct {
+	alpha  float64
+	byName map[string]*Average
+}
+
+// SetAverages makes t maintain exponential moving averages of the
+// duration and error rate of every checkpoint name on Update, alpha in
+// (0, 1] is the weight of a new checkpoint. It is meant for always-on
+// services: memory is constant per name, unlike the checkpoints, and
+// the averages see the checkpoints dropped by the sampler too.
+// A non-positive alpha disables the averages.
+func (t *Track) SetAverages(alpha float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if alpha <= 0 {
+		t.averages = nil
+		return
+	}
+	t.averages = &averages{alpha: min(alpha, 1), byName: make(map[string]*Average)}
+}
+
+func (a *averages) add(m Meta) {
+	avg, ok := a.byName[m.Name]
+	if !ok {
+		avg = &Average{Name: m.Name, Dur: m.Dur}
+		a.byName[m.Name] = avg
+	}
+
+	var failed float64
+	if m.Err != nil {
+		failed = 1
+	}
+	if avg.Count == 0 {
+		avg.ErrorRate = failed
+	} else {
+		avg.Dur += time.Duration(a.alpha * float64(m.Dur-avg.Dur))
+		avg.ErrorRate += a.alpha * (failed - avg.ErrorRate)
+	}
+	avg.Count++
+}
+
+// Average returns the moving average of the checkpoints with the name
+func (t *Track) Average(name string) (Average, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.averages == nil {
+		return Average{}, false
+	}
+	avg, ok := t.averages.byName[name]
+	if !ok {
+		return Average{}, false
+	}
+	return *avg, true
+}
+
+// Averages returns the moving averages ordered by name
+func (t *Track) Averages() []Average {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.averages == nil {
+		return nil
+	}
+	avgs := make([]Average, 0, len(t.averages.byName))
+	for _, avg := range t.averages.byName {
+		avgs = append(avgs, *avg)
+	}
+	sort.Slice(avgs, func(i, j int) bool { return avgs[i].Name < avgs[j].Name })
+	return avgs
+}
@@ -0,0 +1,70 @@
+package tracker
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// BenchstatRender writes the track in Go benchmark result format, a
+// goos/goarch header and one line per checkpoint name with the number of
+// checkpoints and their mean duration:
+//
+//	goos: linux
+//	goarch: amd64
+//	BenchmarkMain.load 3 1204311 ns/op
+//
+// Collect the output of several runs into a file and compare
+// them with benchstat.
+type BenchstatRender struct {
+	Out io.Writer
+}
+
+func (bsr BenchstatRender) Render(data MetaData, opt *Options) {
+	var (
+		names []string
+		count = make(map[string]int64)
+		total = make(map[string]int64)
+	)
+
+	for _, m := range steps(data) {
+		if _, ok := count[m.Name]; !ok {
+			names = append(names, m.Name)
+		}
+		count[m.Name]++
+		total[m.Name] += int64(m.Dur)
+	}
+
+	_, err := fmt.Fprintf(bsr.Out, "goos: %s\ngoarch: %s\n", runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		WriteFailed(bsr.Out, err, "writing benchmark results")
+		return
+	}
+	for _, name := range names {
+		_, err = fmt.Fprintf(bsr.Out, "Benchmark%s %d %d ns/op\n", benchName(name), count[name], total[name]/count[name])
+		if err != nil {
+			WriteFailed(bsr.Out, err, "writing benchmark results")
+			return
+		}
+	}
+}
+
+// benchmark names are whitespace separated fields which must not
+// start with a lower case letter after the "Benchmark" prefix
+func benchName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return '_'
+		}
+		return r
+	}, name)
+
+	r, size := utf8.DecodeRuneInString(name)
+	if size == 0 {
+		return name
+	}
+	return string(unicode.ToUpper(r)) + name[size:]
+}
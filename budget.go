@@ -0,0 +1,24 @@
+package tracker
+
+import "time"
+
+// Budgets holds the allowed durations of checkpoints: by checkpoint
+// name, or Default for the rest. A zero budget means no limit.
+type Budgets struct {
+	Default time.Duration
+	ByName  map[string]time.Duration
+}
+
+// Of returns the budget of the checkpoint with the given name
+func (b Budgets) Of(name string) time.Duration {
+	if d, ok := b.ByName[name]; ok {
+		return d
+	}
+	return b.Default
+}
+
+// Exceeded reports whether m took longer than its budget
+func (b Budgets) Exceeded(m Meta) bool {
+	d := b.Of(m.Name)
+	return d > 0 && m.Dur > d
+}
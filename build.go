@@ -0,0 +1,78 @@
+package tracker
+
+import (
+	"runtime/debug"
+	"sync"
+)
+
+// Build identifies the deploy which made a track, so performance
+// differences across deploys are attributable, see CaptureBuild
+type Build struct {
+	// Version is the version of the main module, "(devel)" if it was
+	// built from a working copy
+	Version  string `json:"version,omitempty"`
+	Commit   string `json:"commit,omitempty"`
+	Modified bool   `json:"modified,omitempty"`
+	// Flags are the feature flags active when the track was created
+	Flags map[string]string `json:"flags,omitempty"`
+}
+
+var (
+	captureBuilds bool
+	buildFlags    func() map[string]string
+
+	buildOnce sync.Once
+	mainBuild Build
+)
+
+// CaptureBuild makes the new tracks capture the version and VCS commit
+// of the binary and the feature flags returned by flags (it may be nil),
+// whether they are made by New, CloneConfig, Factory.NewTrack or their
+// first Update. Dashboard and Export carry them. Call it once at startup,
+// before tracks are created.
+func CaptureBuild(flags func() map[string]string) {
+	captureBuilds = true
+	buildFlags = flags
+}
+
+// captureBuild returns the build of a new track, nil unless CaptureBuild was called
+func captureBuild() *Build {
+	if !captureBuilds {
+		return nil
+	}
+
+	buildOnce.Do(func() {
+		info, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+		mainBuild.Version = info.Main.Version
+		for _, s := range info.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				mainBuild.Commit = s.Value
+			case "vcs.modified":
+				mainBuild.Modified = s.Value == "true"
+			}
+		}
+	})
+
+	b := mainBuild
+	if buildFlags != nil {
+		b.Flags = copyLabels(buildFlags())
+	}
+	return &b
+}
+
+// Build returns the build captured when t was created,
+// nil if it was not captured, see CaptureBuild
+func (t *Track) Build() *Build {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.build == nil {
+		return nil
+	}
+	b := *t.build
+	b.Flags = copyLabels(b.Flags)
+	return &b
+}
@@ -0,0 +1,102 @@
+package tracker
+
+import "encoding/json"
+
+// chunks is an append-only storage of checkpoints in fixed size blocks,
+// growing it never copies the checkpoints which are already stored,
+// so very long-lived tracks avoid copy-on-grow pauses and GC pressure
+type chunks struct {
+	size   int
+	blocks [][]Meta
+	n      int
+}
+
+func newChunks(size int) *chunks {
+	return &chunks{size: size}
+}
+
+func (c *chunks) append(m Meta) {
+	if c.n%c.size == 0 {
+		c.blocks = append(c.blocks, make([]Meta, 0, c.size))
+	}
+	last := len(c.blocks) - 1
+	c.blocks[last] = append(c.blocks[last], m)
+	c.n++
+}
+
+func (c *chunks) at(i int) *Meta {
+	return &c.blocks[i/c.size][i%c.size]
+}
+
+// appendTo appends all the checkpoints to dst in order
+func (c *chunks) appendTo(dst MetaData) MetaData {
+	for _, b := range c.blocks {
+		dst = append(dst, b...)
+	}
+	return dst
+}
+
+// SetChunkSize switches t to chunked storage: checkpoints are kept in blocks
+// of n entries instead of the single growing Data slice. The checkpoints
+// recorded so far are moved into the blocks and Data stays empty from then
+// on: direct reads of the field see nothing, while Get, Len, Snapshot, All,
+// the renderers and the JSON encoding of t see every checkpoint.
+// Recommended for very long-lived tracks, e.g. n = 1024.
+func (t *Track) SetChunkSize(n int) {
+	if n <= 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	c := newChunks(n)
+	for _, m := range t.snapshotLocked() {
+		c.append(m)
+	}
+	t.chunks = c
+	t.Data = nil
+}
+
+// len returns the number of the checkpoints, t.mu must be held
+func (t *Track) len() int {
+	if t.chunks != nil {
+		return t.chunks.n
+	}
+	return len(t.Data)
+}
+
+// at returns the i-th checkpoint, t.mu must be held
+func (t *Track) at(i int) *Meta {
+	if t.chunks != nil {
+		return t.chunks.at(i)
+	}
+	return &t.Data[i]
+}
+
+// push appends the checkpoint into the storage, t.mu must be held
+func (t *Track) push(m Meta) {
+	if t.chunks != nil {
+		t.chunks.append(m)
+		return
+	}
+	t.Data = append(t.Data, m)
+}
+
+// snapshotLocked is snapshot with t.mu held
+func (t *Track) snapshotLocked() MetaData {
+	if t.chunks != nil {
+		return t.chunks.appendTo(make(MetaData, 0, t.chunks.n))
+	}
+	return append(MetaData(nil), t.Data...)
+}
+
+// MarshalJSON encodes t as its fields, with the checkpoints read from
+// the storage, so a chunked track is encoded whole
+func (t *Track) MarshalJSON() ([]byte, error) {
+	type fields Track
+	return json.Marshal(struct {
+		Data MetaData `json:"trackedData,omitempty"`
+		*fields
+	}{t.snapshot(), (*fields)(t)})
+}
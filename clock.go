@@ -0,0 +1,117 @@
+package tracker
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Clock is the time source of a track, see SetClock
+type Clock interface {
+	Now() time.Time
+}
+
+// SimClock is a simulated clock for discrete-event simulations and
+// replay tests: its time starts at the given start, runs speed times as
+// fast as the real time and moves on by Advance. A zero speed stops it
+// between the Advance calls. It is safe for concurrent use.
+type SimClock struct {
+	mu     sync.Mutex
+	start  time.Time
+	real   time.Time
+	speed  float64
+	offset time.Duration
+}
+
+func NewSimClock(start time.Time, speed float64) *SimClock {
+	return &SimClock{start: start, real: time.Now(), speed: speed}
+}
+
+func (c *SimClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elapsed := time.Duration(c.speed * float64(time.Since(c.real)))
+	return c.start.Add(c.offset + elapsed)
+}
+
+// Advance moves the time of the clock on by d
+func (c *SimClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.offset += d
+}
+
+// Simulated marks the checkpoints recorded by the clock as simulated,
+// so the renderers label the timeline
+func (c *SimClock) Simulated() bool {
+	return true
+}
+
+// errClockStarted is returned by SetClock on a track with checkpoints
+var errClockStarted = errors.New("the clock must be set before the first checkpoint")
+
+// SetClock makes t record the checkpoints against the clock instead of
+// the real time, a nil clock is the real time. It must be called before
+// the first checkpoint: the creation checkpoint is moved to the time of
+// the clock, a track with other checkpoints keeps its clock and an
+// error is returned.
+func (t *Track) SetClock(c Clock) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.len() > 1 {
+		return errClockStarted
+	}
+
+	t.clock = c
+	t.simulated = false
+	if s, ok := c.(interface{ Simulated() bool }); ok {
+		t.simulated = s.Simulated()
+	}
+	if t.len() == 1 {
+		first := t.at(0)
+		first.Start = t.nowLocked()
+		first.Simulated = t.simulated
+	}
+	return nil
+}
+
+// now returns the time of the clock of t
+func (t *Track) now() time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.nowLocked()
+}
+
+// nowLocked works as now, t.mu must be held
+func (t *Track) nowLocked() time.Time {
+	if t.clock == nil {
+		return time.Now()
+	}
+	return t.clock.Now()
+}
+
+// simulatedNote is the label of the timelines recorded by a simulated clock
+const simulatedNote = "simulated timeline"
+
+// caption returns the note under the table: the simulated timeline
+// label and the footer
+func (ro *RenderOptions) caption(data MetaData) string {
+	var notes []string
+	if data.simulated() {
+		notes = append(notes, simulatedNote)
+	}
+	if ro.Footer != "" {
+		notes = append(notes, ro.Footer)
+	}
+	return strings.Join(notes, ", ")
+}
+
+func (m MetaData) simulated() bool {
+	for _, e := range m {
+		if e.Simulated {
+			return true
+		}
+	}
+	return false
+}
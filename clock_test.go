@@ -0,0 +1,102 @@
+package tracker
+
+import (
+	"testing"
+	"time"
+)
+
+var simStart = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+// simTrack returns a track created at simStart on a stopped simulated clock
+func simTrack(t *testing.T) (*Track, *SimClock) {
+	t.Helper()
+	tr := New(3)
+	clock := NewSimClock(simStart, 0)
+	if err := tr.SetClock(clock); err != nil {
+		t.Fatal(err)
+	}
+	return tr, clock
+}
+
+// last returns the last checkpoint of the track
+func last(t *testing.T, tr *Track) Meta {
+	t.Helper()
+	m, ok := tr.Snapshot().Last()
+	if !ok {
+		t.Fatal("no checkpoints")
+	}
+	return m
+}
+
+func TestSetClockRebasesCreation(t *testing.T) {
+	tr, clock := simTrack(t)
+	first, _ := tr.Get(0)
+	if !first.Start.Equal(simStart) || !first.Simulated {
+		t.Fatalf("got creation at %v simulated %v, want %v simulated", first.Start, first.Simulated, simStart)
+	}
+
+	clock.Advance(time.Second)
+	tr.Update(nil)
+	if m := last(t, tr); m.Dur != time.Second || m.StartDif != time.Second {
+		t.Errorf("got dur %v since start %v, want 1s", m.Dur, m.StartDif)
+	}
+	if err := tr.SetClock(nil); err == nil {
+		t.Error("SetClock after a checkpoint succeeded")
+	}
+}
+
+func TestZeroValueLazyStartOnSimClock(t *testing.T) {
+	var tr Track
+	clock := NewSimClock(simStart, 0)
+	if err := tr.SetClock(clock); err != nil {
+		t.Fatal(err)
+	}
+
+	clock.Advance(time.Minute)
+	if err := tr.Update(nil); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(2 * time.Second)
+	tr.Update(nil)
+
+	data := tr.Snapshot()
+	if len(data) != 3 {
+		t.Fatalf("got %d checkpoints, want the creation and 2 updates", len(data))
+	}
+	if want := simStart.Add(time.Minute); !data[0].Start.Equal(want) {
+		t.Errorf("got creation at %v, want the clock time %v", data[0].Start, want)
+	}
+	if data[1].Dur != 0 || data[2].Dur != 2*time.Second || data[2].StartDif != 2*time.Second {
+		t.Errorf("got durations %v, %v since start %v", data[1].Dur, data[2].Dur, data[2].StartDif)
+	}
+	for i, m := range data {
+		if !m.Simulated {
+			t.Errorf("checkpoint %d is not simulated", i)
+		}
+	}
+}
+
+func TestStrictZeroValue(t *testing.T) {
+	tr := Track{Strict: true}
+	if err := tr.Update(nil); err == nil {
+		t.Error("Update of a strict zero-value track succeeded")
+	}
+	if n := tr.Len(); n != 0 {
+		t.Errorf("got %d checkpoints, want none", n)
+	}
+}
+
+func TestWaitOnSimClock(t *testing.T) {
+	tr, clock := simTrack(t)
+
+	tr.Wait(func() { clock.Advance(3 * time.Second) })
+	ch := make(chan int, 1)
+	ch <- 1
+	Recv(tr, ch)
+	clock.Advance(time.Second)
+	tr.Update(nil)
+
+	if m := last(t, tr); m.Blocked != 3*time.Second || m.Busy() != time.Second {
+		t.Errorf("got blocked %v busy %v, want 3s of the simulated clock", m.Blocked, m.Busy())
+	}
+}
@@ -0,0 +1,72 @@
+// Command tracker works with the tracks saved by the tracker package.
+//
+//	tracker stitch [-divider n] export.json...
+//	tracker selftest
+//
+// stitch reads tracks saved with Track.Export by several services,
+// stitches the ones sharing a trace ID and prints a timeline per trace
+// with a lane per service.
+//
+// selftest measures the timer resolution of the host and the overhead of
+// the tracker, and tells whether checkpoints under 100µs are trustworthy.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/cat-in-vacuum/tracker"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "stitch":
+		err = stitch(os.Args[2:])
+	case "selftest":
+		fmt.Print(tracker.RunSelfTest())
+	default:
+		usage()
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "tracker:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: tracker stitch [-divider n] export.json...")
+	fmt.Fprintln(os.Stderr, "       tracker selftest")
+	os.Exit(2)
+}
+
+func stitch(args []string) error {
+	fs := flag.NewFlagSet("stitch", flag.ExitOnError)
+	divider := fs.Int("divider", 40, "width of the track column")
+	fs.Parse(args)
+
+	var exports []tracker.Export
+	for _, name := range fs.Args() {
+		b, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		var e tracker.Export
+		if err = json.Unmarshal(b, &e); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		exports = append(exports, e)
+	}
+
+	render := tracker.TableRender{Out: os.Stdout, Options: &tracker.RenderOptions{Divider: *divider}}
+	for _, s := range tracker.Stitch(exports) {
+		render.RenderStitched(s)
+	}
+	return nil
+}
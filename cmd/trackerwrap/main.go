@@ -0,0 +1,274 @@
+// Command trackerwrap generates a tracked decorator of an interface:
+// every call of a method records a checkpoint named Interface.Method
+// with the summary of the arguments and the returned error.
+//
+//	//go:generate trackerwrap -type Store
+//
+// generates store_tracked.go with the TrackedStore decorator in the
+// package of the current directory and registers it for tracker.Wrap.
+// The methods of embedded interfaces declared in other packages are
+// promoted without tracking.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	typeName := flag.String("type", "", "name of the interface")
+	output := flag.String("o", "", "output file, <type>_tracked.go by default")
+	flag.Parse()
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+	if *typeName == "" {
+		fmt.Fprintln(os.Stderr, "usage: trackerwrap -type Interface [-o file] [dir]")
+		os.Exit(2)
+	}
+	if *output == "" {
+		*output = filepath.Join(dir, strings.ToLower(*typeName)+"_tracked.go")
+	}
+
+	src, err := generate(dir, *typeName)
+	if err == nil {
+		err = os.WriteFile(*output, src, 0o644)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "trackerwrap:", err)
+		os.Exit(1)
+	}
+}
+
+// iface is the interface found in the package
+type iface struct {
+	fset    *token.FileSet
+	pkg     string
+	name    string
+	methods []*ast.Field
+	// imports of the file of the interface by package name
+	imports map[string]*ast.ImportSpec
+}
+
+func generate(dir, name string) ([]byte, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pkg := range pkgs {
+		it := &iface{fset: fset, pkg: pkg.Name, name: name}
+		if err = it.find(pkg, name); err != nil {
+			return nil, err
+		}
+		if it.imports != nil {
+			return it.generate()
+		}
+	}
+	return nil, fmt.Errorf("interface %s not found in %s", name, dir)
+}
+
+// find collects the methods of the interface, including the ones of
+// the embedded interfaces declared in the package
+func (it *iface) find(pkg *ast.Package, name string) error {
+	for _, f := range pkg.Files {
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if ts.Name.Name != name {
+					continue
+				}
+				t, ok := ts.Type.(*ast.InterfaceType)
+				if !ok {
+					return fmt.Errorf("%s is not an interface", name)
+				}
+				if ts.TypeParams != nil {
+					return errors.New("generic interfaces are not supported")
+				}
+				if it.imports == nil {
+					it.imports = fileImports(f)
+				}
+				for _, m := range t.Methods.List {
+					switch typ := m.Type.(type) {
+					case *ast.FuncType:
+						it.methods = append(it.methods, m)
+					case *ast.Ident:
+						if err := it.find(pkg, typ.Name); err != nil {
+							return err
+						}
+					}
+				}
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+func fileImports(f *ast.File) map[string]*ast.ImportSpec {
+	imports := make(map[string]*ast.ImportSpec)
+	for _, imp := range f.Imports {
+		p, _ := strconv.Unquote(imp.Path.Value)
+		name := importName(p)
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		imports[name] = imp
+	}
+	return imports
+}
+
+// importName guesses the package name of the import path by the
+// conventions: gopkg.in/yaml.v3 is yaml, github.com/x/go-redis/v9 is redis
+func importName(p string) string {
+	base := path.Base(p)
+	if len(base) > 1 && base[0] == 'v' && strings.Trim(base[1:], "0123456789") == "" {
+		base = path.Base(path.Dir(p))
+	}
+	base = strings.TrimPrefix(base, "go-")
+	if i := strings.IndexAny(base, ".-"); i >= 0 {
+		base = base[:i]
+	}
+	return base
+}
+
+func (it *iface) generate() ([]byte, error) {
+	wrapper := "Tracked" + it.name
+	used := map[string]bool{}
+
+	var body bytes.Buffer
+	for _, m := range it.methods {
+		for _, name := range m.Names {
+			it.method(&body, wrapper, name.Name, m.Type.(*ast.FuncType), used)
+		}
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by trackerwrap; DO NOT EDIT.\n\npackage %s\n\nimport (\n", it.pkg)
+	names := make([]string, 0, len(used))
+	for name := range used {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		imp, ok := it.imports[name]
+		if !ok {
+			return nil, fmt.Errorf("import of package %s not found", name)
+		}
+		if imp.Name != nil {
+			fmt.Fprintf(&b, "\t%s %s\n", imp.Name.Name, imp.Path.Value)
+		} else {
+			fmt.Fprintf(&b, "\t%s\n", imp.Path.Value)
+		}
+	}
+	fmt.Fprintf(&b, "\n\t\"github.com/cat-in-vacuum/tracker\"\n)\n\n")
+
+	fmt.Fprintf(&b, "// %s records the calls of %s into Track\n", wrapper, it.name)
+	fmt.Fprintf(&b, "type %s struct {\n\t%s\n\tTrack *tracker.Track\n}\n\n", wrapper, it.name)
+	fmt.Fprintf(&b, "func init() {\n\ttracker.RegisterWrapper(func(t *tracker.Track, svc %s) %s {\n", it.name, it.name)
+	fmt.Fprintf(&b, "\t\treturn %s{%s: svc, Track: t}\n\t})\n}\n", wrapper, it.name)
+	b.Write(body.Bytes())
+
+	return format.Source(b.Bytes())
+}
+
+// method writes the tracked method of the wrapper
+func (it *iface) method(b *bytes.Buffer, wrapper, name string, fn *ast.FuncType, used map[string]bool) {
+	var params, args, summary, results []string
+	variadic := false
+	for _, f := range fieldList(fn.Params) {
+		arg := fmt.Sprintf("a%d", len(args))
+		typ := it.expr(f.Type, used)
+		if _, ok := f.Type.(*ast.Ellipsis); ok {
+			variadic = true
+		}
+		params = append(params, arg+" "+typ)
+		args = append(args, arg)
+		if typ != "context.Context" {
+			summary = append(summary, arg)
+		}
+	}
+	var errResult string
+	for i, f := range fieldList(fn.Results) {
+		res := fmt.Sprintf("r%d", i)
+		typ := it.expr(f.Type, used)
+		results = append(results, res+" "+typ)
+		if typ == "error" && i == len(fieldList(fn.Results))-1 {
+			errResult = res
+		}
+	}
+	call := fmt.Sprintf("w.%s.%s(%s", it.name, name, strings.Join(args, ", "))
+	if variadic {
+		call += "..."
+	}
+	call += ")"
+
+	fmt.Fprintf(b, "\nfunc (w %s) %s(%s) (%s) {\n", wrapper, name, strings.Join(params, ", "), strings.Join(results, ", "))
+	fmt.Fprintf(b, "\ts := w.Track.Step(%q)", it.name+"."+name)
+	if len(summary) > 0 {
+		fmt.Fprintf(b, ".Attr(\"args\", tracker.Args(%s))", strings.Join(summary, ", "))
+	}
+	if errResult != "" {
+		fmt.Fprintf(b, "\n\tdefer func() { s.Err(%s).Done() }()\n", errResult)
+	} else {
+		b.WriteString("\n\tdefer s.Done()\n")
+	}
+	if len(results) > 0 {
+		fmt.Fprintf(b, "\treturn %s\n}\n", call)
+	} else {
+		fmt.Fprintf(b, "\t%s\n}\n", call)
+	}
+}
+
+// fieldList expands the fields with several names, e.g. (a, b int)
+func fieldList(fl *ast.FieldList) []*ast.Field {
+	if fl == nil {
+		return nil
+	}
+	var fields []*ast.Field
+	for _, f := range fl.List {
+		for range max(len(f.Names), 1) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// expr prints the type expression and marks the packages it refers to
+func (it *iface) expr(e ast.Expr, used map[string]bool) string {
+	ast.Inspect(e, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok {
+				used[id.Name] = true
+			}
+			return false
+		}
+		return true
+	})
+
+	var b bytes.Buffer
+	printer.Fprint(&b, it.fset, e)
+	return b.String()
+}
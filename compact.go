@@ -0,0 +1,95 @@
+package tracker
+
+import (
+	"context"
+	"time"
+)
+
+// Compact folds the checkpoints of t older than age into per-name
+// aggregates, see Compacted, while the recent ones stay raw. The
+// creation checkpoint and the pinned ones are never folded.
+func (t *Track) Compact(age time.Duration) {
+	cutoff := t.now().Add(-age)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n := t.len()
+	if n == 0 {
+		return
+	}
+
+	kept := make(MetaData, 0, n)
+	kept = append(kept, *t.at(0))
+	for i := 1; i < n; i++ {
+		m := t.at(i)
+		if m.Pinned || !m.Start.Before(cutoff) {
+			kept = append(kept, *m)
+			continue
+		}
+		t.fold(*m)
+	}
+	if len(kept) == n {
+		return
+	}
+
+	// the storage is rebuilt, so the memory of the folded checkpoints is freed
+	if t.chunks != nil {
+		c := newChunks(t.chunks.size)
+		for _, m := range kept {
+			c.append(m)
+		}
+		t.chunks = c
+		return
+	}
+	t.Data = kept
+}
+
+// fold adds the checkpoint to the aggregate of its name, t.mu must be held
+func (t *Track) fold(m Meta) {
+	i, ok := t.compactedIndex[m.Name]
+	if !ok {
+		if t.compactedIndex == nil {
+			t.compactedIndex = make(map[string]int)
+		}
+		i = len(t.compacted)
+		t.compactedIndex[m.Name] = i
+		t.compacted = append(t.compacted, Group{Key: m.Name})
+	}
+
+	g := &t.compacted[i]
+	g.Count++
+	g.Total += m.Dur
+	if m.Dur > g.Max {
+		g.Max = m.Dur
+	}
+	if m.Err != nil {
+		g.Errors++
+	}
+}
+
+// Compacted returns the aggregates of the folded checkpoints by name,
+// ordered by the first fold of the name
+func (t *Track) Compacted() []Group {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]Group(nil), t.compacted...)
+}
+
+// CompactEvery compacts t every period until ctx is done, the memory of
+// a long-lived track stays flat while its history is kept in aggregates
+func (t *Track) CompactEvery(ctx context.Context, age, period time.Duration) {
+	go func() {
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				t.Compact(age)
+			}
+		}
+	}()
+}
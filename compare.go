@@ -0,0 +1,86 @@
+package tracker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// RenderComparison renders several tracks (e.g. the same pipeline on
+// different inputs) side by side: a row per checkpoint name, a column
+// of summed durations per track and a delta column of the last track
+// against the first one. labels name the columns of the sets, a name
+// missing in a set is reported as absent there.
+func (tbr TableRender) RenderComparison(labels []string, sets ...MetaData) {
+	if len(sets) == 0 {
+		return
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+	totals := make([]map[string]time.Duration, len(sets))
+
+	for i, data := range sets {
+		totals[i] = make(map[string]time.Duration)
+		for _, m := range steps(data) {
+			if !seen[m.Name] {
+				seen[m.Name] = true
+				names = append(names, m.Name)
+			}
+			totals[i][m.Name] += m.Dur
+		}
+	}
+
+	headers := []string{"func.name"}
+	for i := range sets {
+		if i < len(labels) {
+			headers = append(headers, labels[i])
+		} else {
+			headers = append(headers, fmt.Sprintf("#%d", i+1))
+		}
+	}
+	headers = append(headers, "delta")
+
+	out := capture(tbr.Out)
+	table := tablewriter.NewWriter(out)
+	table.SetHeader(headers)
+
+	for _, name := range names {
+		row := []string{name}
+		for i := range sets {
+			if d, ok := totals[i][name]; ok {
+				row = append(row, d.String())
+			} else {
+				row = append(row, "absent")
+			}
+		}
+		row = append(row, delta(totals[0], totals[len(totals)-1], name))
+		table.Append(row)
+	}
+
+	table.Render()
+	if err := out.Err(); err != nil {
+		WriteFailed(tbr.Out, err, "writing data")
+	}
+}
+
+// delta of the named duration in b against a, e.g. "+1.2ms (+15.0%)"
+func delta(a, b map[string]time.Duration, name string) string {
+	from, okA := a[name]
+	to, okB := b[name]
+	if !okA || !okB {
+		return ""
+	}
+
+	d := to - from
+	sign := "+"
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	if from == 0 {
+		return sign + d.String()
+	}
+	return fmt.Sprintf("%s%s (%+.1f%%)", sign, d, float64(to-from)/float64(from)*100)
+}
@@ -0,0 +1,16 @@
+package tracker
+
+import "context"
+
+type ctxKey struct{}
+
+// NewContext returns a copy of ctx which carries the Track t.
+func NewContext(ctx context.Context, t *Track) context.Context {
+	return context.WithValue(ctx, ctxKey{}, t)
+}
+
+// FromContext returns the Track stored in ctx, or nil if there is none.
+func FromContext(ctx context.Context) *Track {
+	t, _ := ctx.Value(ctxKey{}).(*Track)
+	return t
+}
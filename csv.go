@@ -0,0 +1,46 @@
+package tracker
+
+import (
+	"encoding/csv"
+	"io"
+	"time"
+)
+
+// CSVRender writes the track as CSV for spreadsheets, the columns are the
+// ones of the table except the track bar. Set Options.NumericUnit to write
+// the durations as plain numbers which formulas can compute with.
+type CSVRender struct {
+	Out     io.Writer
+	Options *RenderOptions
+}
+
+func (cr CSVRender) Render(data MetaData, opt *Options) {
+	if opt == nil {
+		opt = defaultOptions()
+	}
+	columns := *opt
+	columns.withTrack = false
+
+	f := sameFormats(time.Duration.String)
+	headers := createHeaders(nil, &columns)
+	if cr.Options != nil && cr.Options.NumericUnit > 0 {
+		unit := cr.Options.NumericUnit
+		f = sameFormats(numericFormat(unit))
+		for i, h := range headers {
+			switch h {
+			case "since.start", "duration", "busy":
+				headers[i] = h + " (" + unitName(unit) + ")"
+			}
+		}
+	}
+
+	w := csv.NewWriter(cr.Out)
+	w.Write(headers)
+	for _, m := range data {
+		w.Write(createRow(nil, &columns, m, "", f))
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		WriteFailed(cr.Out, err, "writing data")
+	}
+}
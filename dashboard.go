@@ -0,0 +1,86 @@
+package tracker
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// DashboardVersion is the version of the Dashboard data contract,
+// it changes only on incompatible changes of the JSON layout.
+const DashboardVersion = 1
+
+// Dashboard is the stable JSON data contract for frontend dashboards.
+// Durations are integer nanoseconds, so no client side parsing
+// of Go duration strings is needed.
+type Dashboard struct {
+	Version     int                   `json:"version"`
+	Start       time.Time             `json:"start"`
+	TotalNs     int64                 `json:"total_ns"`
+	MaxNs       int64                 `json:"max_ns"`
+	Errors      int                   `json:"errors"`
+	Checkpoints []DashboardCheckpoint `json:"checkpoints"`
+	// Averages are served by Track.Handler if the track keeps them
+	Averages []Average `json:"averages,omitempty"`
+	// Build is served by Track.Handler if it was captured, see CaptureBuild
+	Build *Build `json:"build,omitempty"`
+	// Compacted are served by Track.Handler if the track was compacted
+	Compacted []Group `json:"compacted,omitempty"`
+}
+
+// DashboardCheckpoint is a single checkpoint of the Dashboard,
+// the first one is the creation of the track.
+type DashboardCheckpoint struct {
+	Index int       `json:"index"`
+	Name  string    `json:"name"`
+	Time  time.Time `json:"time"`
+	// OffsetNs is the time since the start of the track
+	OffsetNs int64 `json:"offset_ns"`
+	// DurationNs is the time since the previous checkpoint
+	DurationNs int64  `json:"duration_ns"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Dashboard converts the data into the dashboard data contract
+func (m MetaData) Dashboard() Dashboard {
+	d := Dashboard{
+		Version:     DashboardVersion,
+		MaxNs:       int64(m.MaxDuration()),
+		Checkpoints: make([]DashboardCheckpoint, 0, len(m)),
+	}
+	if len(m) > 0 {
+		d.Start = m[0].Start
+		d.TotalNs = int64(m[len(m)-1].StartDif)
+	}
+
+	for i, e := range m {
+		c := DashboardCheckpoint{
+			Index:      i,
+			Name:       e.Name,
+			Time:       e.Start,
+			OffsetNs:   int64(e.StartDif),
+			DurationNs: int64(e.Dur),
+		}
+		if e.Err != nil {
+			c.Error = e.Err.Error()
+			d.Errors++
+		}
+		d.Checkpoints = append(d.Checkpoints, c)
+	}
+	return d
+}
+
+// Handler returns a http.Handler serving the current state
+// of the track as Dashboard JSON.
+func (t *Track) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		d := t.snapshot().Dashboard()
+		d.Averages = t.Averages()
+		d.Build = t.Build()
+		d.Compacted = t.Compacted()
+		if err := json.NewEncoder(w).Encode(d); err != nil {
+			WriteFailed(w, err, "writing dashboard")
+		}
+	})
+}
@@ -0,0 +1,149 @@
+package tracker
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+)
+
+// DumpVersion is the version of the Dump format, it changes only
+// on incompatible changes of the JSON layout
+const DumpVersion = 1
+
+// Dump is the stable JSON format for IDE and debugger plugins which
+// overlay the timing of a run inline on the source: the checkpoints are
+// the spans of the run, and Files index them by the call site line.
+// Durations are integer nanoseconds.
+type Dump struct {
+	Version int        `json:"version"`
+	Start   time.Time  `json:"start"`
+	Spans   []DumpSpan `json:"spans"`
+	// Files are ordered by path, their lines by number
+	Files []DumpFile `json:"files"`
+}
+
+// DumpSpan is a checkpoint of the Dump, the first one is the creation of
+// the track. The span runs from StartNs to StartNs+DurationNs since the
+// start of the track.
+type DumpSpan struct {
+	Index      int               `json:"index"`
+	Name       string            `json:"name"`
+	File       string            `json:"file,omitempty"`
+	Line       int               `json:"line,omitempty"`
+	StartNs    int64             `json:"start_ns"`
+	DurationNs int64             `json:"duration_ns"`
+	Error      string            `json:"error,omitempty"`
+	Phase      string            `json:"phase,omitempty"`
+	Attrs      map[string]string `json:"attrs,omitempty"`
+	Notes      []string          `json:"notes,omitempty"`
+}
+
+// DumpFile is a source file with the checkpoints made in it
+type DumpFile struct {
+	Path  string     `json:"path"`
+	Lines []DumpLine `json:"lines"`
+}
+
+// DumpLine sums up the spans of a call site, the text an IDE shows at the line
+type DumpLine struct {
+	Line    int   `json:"line"`
+	Count   int   `json:"count"`
+	TotalNs int64 `json:"total_ns"`
+	MaxNs   int64 `json:"max_ns"`
+	Errors  int   `json:"errors"`
+	// Spans are the indexes of the spans of the line
+	Spans []int `json:"spans"`
+}
+
+// Dump converts the data into the dump format
+func (m MetaData) Dump() Dump {
+	d := Dump{Version: DumpVersion, Spans: make([]DumpSpan, 0, len(m)), Files: []DumpFile{}}
+	if len(m) > 0 {
+		d.Start = m[0].Start
+	}
+
+	lines := make(map[string]map[int]*DumpLine)
+	for i, e := range m {
+		s := DumpSpan{
+			Index:      i,
+			Name:       e.Name,
+			File:       e.File,
+			Line:       e.Line,
+			StartNs:    int64(e.StartDif - e.Dur),
+			DurationNs: int64(e.Dur),
+			Phase:      e.Phase,
+			Attrs:      e.Attrs,
+			Notes:      e.Notes,
+		}
+		if e.Err != nil {
+			s.Error = e.Err.Error()
+		}
+		d.Spans = append(d.Spans, s)
+
+		if e.File == "" {
+			continue
+		}
+		if lines[e.File] == nil {
+			lines[e.File] = make(map[int]*DumpLine)
+		}
+		l := lines[e.File][e.Line]
+		if l == nil {
+			l = &DumpLine{Line: e.Line}
+			lines[e.File][e.Line] = l
+		}
+		l.Count++
+		l.TotalNs += s.DurationNs
+		l.MaxNs = max(l.MaxNs, s.DurationNs)
+		if e.Err != nil {
+			l.Errors++
+		}
+		l.Spans = append(l.Spans, i)
+	}
+
+	for path, byLine := range lines {
+		f := DumpFile{Path: path}
+		for _, l := range byLine {
+			f.Lines = append(f.Lines, *l)
+		}
+		sort.Slice(f.Lines, func(i, j int) bool { return f.Lines[i].Line < f.Lines[j].Line })
+		d.Files = append(d.Files, f)
+	}
+	sort.Slice(d.Files, func(i, j int) bool { return d.Files[i].Path < d.Files[j].Path })
+	return d
+}
+
+// At returns the summary of the call site at the line of the file
+func (d Dump) At(path string, line int) (DumpLine, bool) {
+	i := sort.Search(len(d.Files), func(i int) bool { return d.Files[i].Path >= path })
+	if i == len(d.Files) || d.Files[i].Path != path {
+		return DumpLine{}, false
+	}
+	lines := d.Files[i].Lines
+	j := sort.Search(len(lines), func(j int) bool { return lines[j].Line >= line })
+	if j == len(lines) || lines[j].Line != line {
+		return DumpLine{}, false
+	}
+	return lines[j], true
+}
+
+// ReadDump decodes a dump written by DumpRender
+func ReadDump(r io.Reader) (Dump, error) {
+	var d Dump
+	err := json.NewDecoder(r).Decode(&d)
+	return d, err
+}
+
+// DumpRender writes the track in the dump format, e.g. into a file
+// next to the binary which an IDE plugin picks up after the run
+type DumpRender struct {
+	Out io.Writer
+}
+
+func (dr DumpRender) Render(data MetaData, opt *Options) {
+	enc := json.NewEncoder(dr.Out)
+	enc.SetIndent("", "\t")
+	if err := enc.Encode(data.Dump()); err != nil {
+		WriteFailed(dr.Out, err, "writing dump")
+	}
+}
@@ -0,0 +1,43 @@
+package tracker
+
+import "math/rand/v2"
+
+// Enabled reports whether t records checkpoints, so callers can skip
+// building expensive attribute values of the checkpoints which are
+// dropped anyway, the way logging libraries expose level checks:
+//
+//	if t.Enabled() {
+//		s.Attr("query", render(q))
+//	}
+//
+// It is false for tracks disabled by SetEnabled or by the track rate of
+// their factory, and for Strict tracks which are not started. The
+// Sampler decides on every checkpoint after it is measured, Enabled does
+// not tell its decision in advance.
+func (t *Track) Enabled() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return !t.disabled && (t.len() > 0 || !t.Strict)
+}
+
+// SetEnabled enables or disables the recording of checkpoints, e.g. to
+// track only the requests with a debug header, a disabled track drops
+// the checkpoints of Update, Step and the rest
+func (t *Track) SetEnabled(enabled bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.disabled = !enabled
+}
+
+// SetTrackRate makes the factory enable only the fraction p of the tracks
+// it creates, picked at random, the other ones are disabled as a whole
+// (see Track.Enabled). Unlike a Sampler which drops single checkpoints,
+// the recorded tracks are complete.
+func (f *Factory) SetTrackRate(p float64) {
+	f.skipTracks = 1 - min(max(p, 0), 1)
+}
+
+// sampleTrack reports whether the factory disables the next track
+func (f *Factory) sampleTrack() bool {
+	return f.skipTracks > 0 && rand.Float64() < f.skipTracks
+}
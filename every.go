@@ -0,0 +1,99 @@
+package tracker
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+)
+
+// RenderEvery renders t with its renderer every period until ctx is done,
+// e.g. the progress of a long running job into its log. With delta set
+// only the creation checkpoint, the checkpoints made since the previous
+// render and the pinned ones are rendered, and nothing is rendered if
+// there are no new checkpoints, so the periodic output stays short
+// instead of repeating the whole history. A TableRender writes the
+// cumulative totals of the whole track under the table.
+func (t *Track) RenderEvery(ctx context.Context, period time.Duration, delta bool) {
+	go func() {
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+
+		rendered := 0
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			if delta {
+				rendered = t.renderDelta(rendered)
+			} else {
+				t.Render()
+			}
+		}
+	}()
+}
+
+// renderDelta renders the checkpoints with a Seq after the rendered one
+// and the pinned ones, it returns the Seq of the last rendered checkpoint
+func (t *Track) renderDelta(rendered int) int {
+	data := t.snapshot()
+	if len(data) == 0 {
+		return rendered
+	}
+
+	delta := MetaData{data[0]}
+	var last int
+	for _, m := range steps(data) {
+		if int(m.Seq) > rendered {
+			last = int(m.Seq)
+		} else if !m.Pinned {
+			continue
+		}
+		delta = append(delta, m)
+	}
+	if last == 0 {
+		return rendered
+	}
+
+	var r Renderer = TableRender{Out: os.Stdout}
+	if t.Renderer != nil {
+		r = t.Renderer
+	}
+	if tbr, ok := r.(TableRender); ok {
+		ro := RenderOptions{}
+		if tbr.Options != nil {
+			ro = *tbr.Options
+		}
+		ro.Footer = cumulative(data)
+		tbr.Options = &ro
+		r = tbr
+	}
+	r.Render(delta, t.options)
+	return last
+}
+
+// cumulative sums up all the steps of data for the footer of a delta
+// render, e.g. "total of 1,204 checkpoints in 3m5s, 2 errors"
+func cumulative(data MetaData) string {
+	var total time.Duration
+	var errs int
+	for _, m := range steps(data) {
+		total += m.Dur
+		if m.Err != nil {
+			errs++
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("total of " + groupDigits(len(steps(data))) + " checkpoints in " + total.String())
+	switch {
+	case errs == 1:
+		b.WriteString(", 1 error")
+	case errs > 1:
+		b.WriteString(", " + groupDigits(errs) + " errors")
+	}
+	return b.String()
+}
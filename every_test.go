@@ -0,0 +1,63 @@
+package tracker
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderDelta(t *testing.T) {
+	tr, clock := simTrack(t)
+	var out bytes.Buffer
+	tr.SetRenderer(TableRender{Out: &out})
+
+	clock.Advance(time.Second)
+	tr.UpdatePinned(nil)
+	clock.Advance(time.Second)
+	tr.Update(errors.New("boom"))
+
+	rendered := tr.renderDelta(0)
+	if rendered != 2 {
+		t.Fatalf("got the last rendered seq %d, want 2", rendered)
+	}
+	if s := out.String(); strings.Count(s, "tracker.") != 3 || !strings.Contains(s, "total of 2 checkpoints in 2s, 1 error") {
+		t.Errorf("got first delta\n%s", s)
+	}
+
+	out.Reset()
+	if r := tr.renderDelta(rendered); r != rendered || out.Len() != 0 {
+		t.Errorf("got seq %d and output %q without new checkpoints", r, out.String())
+	}
+
+	clock.Advance(time.Second)
+	tr.Update(nil)
+	rendered = tr.renderDelta(rendered)
+	s := out.String()
+	if rendered != 3 || !strings.Contains(s, "total of 3 checkpoints in 3s, 1 error") {
+		t.Errorf("got seq %d and second delta\n%s", rendered, s)
+	}
+	// the creation, the pinned and the new checkpoint, not the old error
+	if strings.Contains(s, "boom") || strings.Count(s, "tracker.") != 3 {
+		t.Errorf("got second delta\n%s", s)
+	}
+}
+
+func TestRenderDeltaTAP(t *testing.T) {
+	tr, clock := simTrack(t)
+	var out bytes.Buffer
+	tr.SetRenderer(TAPRender{Out: &out})
+
+	clock.Advance(time.Second)
+	tr.Update(nil)
+	tr.renderDelta(0)
+	clock.Advance(time.Second)
+	tr.Update(nil)
+	out.Reset()
+	tr.renderDelta(1)
+
+	if s := out.String(); !strings.Contains(s, "1..1\n") {
+		t.Errorf("got delta\n%s\nwant the plan of the single new step", s)
+	}
+}
@@ -0,0 +1,85 @@
+package tracker
+
+import "context"
+
+// Factory is configured once and then creates per-request tracks
+// in hot paths (middleware, handlers, workers), so every track
+// shares the same options, renderer and logging behaviour.
+type Factory struct {
+	Loggable   bool
+	callerSkip int
+	maxDepth   int
+	chunkSize  int
+	sampler    Sampler
+	ignore     *ignore
+	rewrites   []Rewrite
+	skipTracks float64
+	options    *Options
+	renderer   Renderer
+}
+
+func NewFactory(callerSkip int) *Factory {
+	return &Factory{callerSkip: callerSkip}
+}
+
+// Configure works the same way as Track.Configure, the options
+// are copied into every track created by the factory.
+func (f *Factory) Configure() *Options {
+	f.options = new(Options)
+	return f.options
+}
+
+func (f *Factory) SetRenderer(render Renderer) {
+	f.renderer = render
+}
+
+// SetChunkSize works the same way as Track.SetChunkSize
+func (f *Factory) SetChunkSize(n int) {
+	f.chunkSize = n
+}
+
+// SetSampler works the same way as Track.SetSampler,
+// the sampler is shared by all the tracks of the factory
+func (f *Factory) SetSampler(s Sampler) {
+	f.sampler = s
+}
+
+// SetIgnore works the same way as Track.SetIgnore
+func (f *Factory) SetIgnore(ig Ignore) {
+	f.ignore = newIgnore(ig)
+}
+
+// SetRewrites works the same way as Track.SetRewrites
+func (f *Factory) SetRewrites(rules ...Rewrite) {
+	f.rewrites = append([]Rewrite(nil), rules...)
+}
+
+// SetMaxDepth works the same way as Track.SetMaxDepth
+func (f *Factory) SetMaxDepth(n int) {
+	f.maxDepth = n
+}
+
+// NewTrack creates a Track from the factory configuration and
+// returns it together with a copy of ctx which carries it (see FromContext).
+func (f *Factory) NewTrack(ctx context.Context) (context.Context, *Track) {
+	t := &Track{
+		Loggable:   f.Loggable,
+		callerSkip: f.callerSkip,
+		depth:      stackDepth(),
+		maxDepth:   f.maxDepth,
+		sampler:    f.sampler,
+		ignore:     f.ignore,
+		rewrites:   f.rewrites,
+		Renderer:   f.renderer,
+		disabled:   f.sampleTrack(),
+	}
+	if f.options != nil {
+		opt := *f.options
+		t.options = &opt
+	}
+	if f.chunkSize > 0 {
+		t.chunks = newChunks(f.chunkSize)
+	}
+	t.start(trace(t.callerSkip))
+	return NewContext(ctx, t), t
+}
@@ -0,0 +1,116 @@
+package tracker
+
+import (
+	"io"
+	"log"
+	"time"
+)
+
+// WriteFailure is what happens when writing the rendered output fails
+type WriteFailure int
+
+const (
+	// LogFailure logs the error and drops the rest of the output
+	LogFailure WriteFailure = iota
+	// PanicOnFailure panics with the error
+	PanicOnFailure
+	// ReturnFailure keeps the error for PolicyWriter.Err without logging it
+	ReturnFailure
+	// RetryFailure retries the write with a backoff, the error
+	// is logged if the last retry fails too
+	RetryFailure
+)
+
+// WritePolicy is the handling of the failures of writing the output of
+// renderers, e.g. for exporters which must not lose reports silently:
+//
+//	out := tracker.WritePolicy{OnFailure: tracker.ReturnFailure}.Wrap(conn)
+//	tracker.JSONRender{Out: out}.Render(t.Snapshot(), nil)
+//	if err := out.Err(); err != nil {
+//		...
+//	}
+type WritePolicy struct {
+	OnFailure WriteFailure
+	// Retries is the number of the retries of RetryFailure, 3 by default
+	Retries int
+	// Backoff is the delay before the first retry, doubled on every
+	// next one, 100ms by default
+	Backoff time.Duration
+}
+
+// Wrap returns w writing with the policy, use it as the Out of a renderer
+func (p WritePolicy) Wrap(w io.Writer) *PolicyWriter {
+	if p.Retries <= 0 {
+		p.Retries = 3
+	}
+	if p.Backoff <= 0 {
+		p.Backoff = 100 * time.Millisecond
+	}
+	return &PolicyWriter{w: w, policy: p}
+}
+
+// PolicyWriter is a writer applying a WritePolicy, see WritePolicy.Wrap
+type PolicyWriter struct {
+	w      io.Writer
+	policy WritePolicy
+	err    error
+}
+
+func (pw *PolicyWriter) Write(p []byte) (int, error) {
+	if pw.err != nil {
+		return 0, pw.err
+	}
+
+	n, err := pw.w.Write(p)
+	if err != nil && pw.policy.OnFailure == RetryFailure {
+		backoff := pw.policy.Backoff
+		for i := 0; i < pw.policy.Retries && err != nil; i++ {
+			time.Sleep(backoff)
+			backoff *= 2
+
+			var m int
+			m, err = pw.w.Write(p[n:])
+			n += m
+		}
+	}
+	if err == nil {
+		return n, nil
+	}
+
+	if pw.policy.OnFailure == PanicOnFailure {
+		panic(err)
+	}
+	// the output is broken, the rest of it is dropped
+	pw.err = err
+	return n, err
+}
+
+// Err returns the first error of writing, nil if all the output was written
+func (pw *PolicyWriter) Err() error {
+	return pw.err
+}
+
+// WriteFailed handles the error of rendering into out, it is exported
+// for the renderers of other packages: if out is a PolicyWriter the error
+// is kept for its Err and its policy applies, otherwise it is logged.
+// what is the failed action, e.g. "writing data".
+func WriteFailed(out io.Writer, err error, what string) {
+	if pw, ok := out.(*PolicyWriter); ok {
+		if pw.err == nil {
+			pw.err = err
+		}
+		switch pw.policy.OnFailure {
+		case ReturnFailure:
+			return
+		case PanicOnFailure:
+			panic(err)
+		}
+	}
+	log.Printf("err:%s; error %s", err.Error(), what)
+}
+
+// capture returns a writer into out keeping the first error of writing,
+// for the writers which drop the errors like the tablewriter package
+func capture(out io.Writer) *PolicyWriter {
+	return WritePolicy{OnFailure: ReturnFailure}.Wrap(out)
+}
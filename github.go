@@ -0,0 +1,47 @@
+package tracker
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// GitHubRender writes GitHub Actions workflow commands: an ::error line
+// for every checkpoint with an error and a ::warning line for every
+// checkpoint over its budget, so tracked CI steps annotate the run and the PR.
+// Nothing is written for the checkpoints which are fine.
+type GitHubRender struct {
+	Out     io.Writer
+	Budgets Budgets
+}
+
+func (ghr GitHubRender) Render(data MetaData, opt *Options) {
+	for _, m := range data {
+		if m.Err != nil {
+			ghr.command("error", m.Name, m.Err.Error())
+		}
+		if ghr.Budgets.Exceeded(m) {
+			ghr.command("warning", m.Name, fmt.Sprintf("took %s, budget %s", m.Dur, ghr.Budgets.Of(m.Name)))
+		}
+	}
+}
+
+func (ghr GitHubRender) command(level, title, msg string) {
+	_, err := fmt.Fprintf(ghr.Out, "::%s title=%s::%s\n", level, escapeProperty(title), escapeData(msg))
+	if err != nil {
+		WriteFailed(ghr.Out, err, "writing annotation")
+	}
+}
+
+var (
+	dataEscaper     = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	propertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+func escapeData(s string) string {
+	return dataEscaper.Replace(s)
+}
+
+func escapeProperty(s string) string {
+	return propertyEscaper.Replace(s)
+}
@@ -1 +1,7 @@
 module github.com/cat-in-vacuum/tracker
+
+go 1.25.0
+
+require github.com/olekukonko/tablewriter v0.0.5
+
+require github.com/mattn/go-runewidth v0.0.9 // indirect
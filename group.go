@@ -0,0 +1,89 @@
+package tracker
+
+import (
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// Group is a roll up of the steps which share the same key
+type Group struct {
+	Key    string        `json:"key"`
+	Count  int           `json:"count"`
+	Errors int           `json:"errors"`
+	Total  time.Duration `json:"total"`
+	Max    time.Duration `json:"max"`
+}
+
+// GroupBy rolls up the steps by the key, groups are ordered by the
+// first appearance of the key. For a per-subsystem breakdown use
+//
+//	data.GroupBy(tracker.Meta.Package)
+func (m MetaData) GroupBy(key func(Meta) string) []Group {
+	groups := []Group{}
+	index := make(map[string]int)
+
+	for _, e := range steps(m) {
+		k := key(e)
+		i, ok := index[k]
+		if !ok {
+			i = len(groups)
+			index[k] = i
+			groups = append(groups, Group{Key: k})
+		}
+
+		g := &groups[i]
+		g.Count++
+		g.Total += e.Dur
+		if e.Dur > g.Max {
+			g.Max = e.Dur
+		}
+		if e.Err != nil {
+			g.Errors++
+		}
+	}
+	return groups
+}
+
+// Package returns the import path of the package of the function which
+// made the checkpoint, or an empty string if the checkpoint was not
+// made by a resolved caller. It is taken when the caller is resolved,
+// so it stays the same when Step, Record, Scope or a rewrite rule
+// changes the name.
+func (iter Meta) Package() string {
+	return iter.Pkg
+}
+
+// funcPackage returns the import path of the package of the function
+// named like runtime.Frame.Function, e.g. "github.com/a/b.(*T).Run.func1"
+func funcPackage(name string) string {
+	slash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[slash+1:], ".")
+	if dot < 0 {
+		return ""
+	}
+	return name[:slash+1+dot]
+}
+
+// RenderGroups renders the groups made by MetaData.GroupBy as a table
+func (tbr TableRender) RenderGroups(groups []Group) {
+	out := capture(tbr.Out)
+	table := tablewriter.NewWriter(out)
+	table.SetHeader([]string{"group", "count", "total", "max", "errors"})
+
+	for _, g := range groups {
+		table.Append([]string{
+			g.Key,
+			tbr.count(int64(g.Count)),
+			g.Total.String(),
+			g.Max.String(),
+			tbr.count(int64(g.Errors)),
+		})
+	}
+
+	table.Render()
+	if err := out.Err(); err != nil {
+		WriteFailed(tbr.Out, err, "writing data")
+	}
+}
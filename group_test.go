@@ -0,0 +1,41 @@
+package tracker
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestPackageOfRenamedCheckpoints(t *testing.T) {
+	tr, _ := simTrack(t)
+	tr.SetRewrites(Rewrite{Pattern: regexp.MustCompile(`^.*\.`), Replacement: "renamed."})
+
+	tr.Update(nil)
+	tr.Step("load").Done()
+	tr.Record("fetch", simStart, time.Millisecond, nil)
+	tr.Scope("db").Update(nil)
+
+	const pkg = "github.com/cat-in-vacuum/tracker"
+	for _, m := range tr.Snapshot()[1:] {
+		if got := m.Package(); got != pkg {
+			t.Errorf("%s: got package %q, want %q", m.Name, got, pkg)
+		}
+	}
+
+	groups := tr.Snapshot().GroupBy(Meta.Package)
+	if len(groups) != 1 || groups[0].Key != pkg || groups[0].Count != 4 {
+		t.Errorf("got groups %+v, want the 4 steps in %s", groups, pkg)
+	}
+}
+
+func TestFuncPackage(t *testing.T) {
+	for name, want := range map[string]string{
+		"github.com/a/b.(*T).Run.func1": "github.com/a/b",
+		"main.main":                     "main",
+		"":                              "",
+	} {
+		if got := funcPackage(name); got != want {
+			t.Errorf("funcPackage(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
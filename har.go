@@ -0,0 +1,137 @@
+package tracker
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// HARRender writes the track as a HAR-like (HTTP Archive 1.2) JSON document:
+// every step is an entry with its start time and duration, the checkpoint
+// name is used as the request URL, failed steps get the 500 status
+// with the error as status text. Waterfall viewers which import HAR files
+// (browser devtools, online HAR viewers) can show tracks then.
+type HARRender struct {
+	Out io.Writer
+}
+
+type harLog struct {
+	Log struct {
+		Version string     `json:"version"`
+		Creator harCreator `json:"creator"`
+		Pages   []harPage  `json:"pages"`
+		Entries []harEntry `json:"entries"`
+	} `json:"log"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harPage struct {
+	StartedDateTime string         `json:"startedDateTime"`
+	ID              string         `json:"id"`
+	Title           string         `json:"title"`
+	PageTimings     map[string]int `json:"pageTimings"`
+}
+
+type harEntry struct {
+	PageRef         string             `json:"pageref"`
+	StartedDateTime string             `json:"startedDateTime"`
+	Time            float64            `json:"time"`
+	Request         harRequest         `json:"request"`
+	Response        harResponse        `json:"response"`
+	Cache           struct{}           `json:"cache"`
+	Timings         map[string]float64 `json:"timings"`
+}
+
+type harRequest struct {
+	Method      string     `json:"method"`
+	URL         string     `json:"url"`
+	HTTPVersion string     `json:"httpVersion"`
+	Headers     []struct{} `json:"headers"`
+	QueryString []struct{} `json:"queryString"`
+	Cookies     []struct{} `json:"cookies"`
+	HeadersSize int        `json:"headersSize"`
+	BodySize    int        `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int        `json:"status"`
+	StatusText  string     `json:"statusText"`
+	HTTPVersion string     `json:"httpVersion"`
+	Headers     []struct{} `json:"headers"`
+	Cookies     []struct{} `json:"cookies"`
+	Content     struct {
+		Size     int    `json:"size"`
+		MimeType string `json:"mimeType"`
+	} `json:"content"`
+	RedirectURL string `json:"redirectURL"`
+	HeadersSize int    `json:"headersSize"`
+	BodySize    int    `json:"bodySize"`
+}
+
+func (hr HARRender) Render(data MetaData, opt *Options) {
+	var doc harLog
+	doc.Log.Version = "1.2"
+	doc.Log.Creator = harCreator{Name: "tracker", Version: "1"}
+	doc.Log.Entries = make([]harEntry, 0, len(data))
+
+	if len(data) > 0 {
+		doc.Log.Pages = []harPage{{
+			StartedDateTime: harTime(data[0].Start),
+			ID:              "track",
+			Title:           data[0].Name,
+			PageTimings:     map[string]int{"onContentLoad": -1, "onLoad": -1},
+		}}
+	}
+
+	for _, m := range steps(data) {
+		ms := float64(m.Dur) / float64(time.Millisecond)
+		e := harEntry{
+			PageRef:         "track",
+			StartedDateTime: harTime(m.Start.Add(-m.Dur)),
+			Time:            ms,
+			Request: harRequest{
+				Method:      "TRACK",
+				URL:         m.Name,
+				HTTPVersion: "HTTP/1.1",
+				Headers:     []struct{}{},
+				QueryString: []struct{}{},
+				Cookies:     []struct{}{},
+				HeadersSize: -1,
+				BodySize:    -1,
+			},
+			Response: harResponse{
+				Status:      200,
+				StatusText:  "OK",
+				HTTPVersion: "HTTP/1.1",
+				Headers:     []struct{}{},
+				Cookies:     []struct{}{},
+				HeadersSize: -1,
+				BodySize:    -1,
+			},
+			Timings: map[string]float64{"send": 0, "wait": ms, "receive": 0},
+		}
+		if m.Err != nil {
+			e.Response.Status = 500
+			e.Response.StatusText = m.Err.Error()
+		}
+		e.Response.Content.MimeType = "text/plain"
+		doc.Log.Entries = append(doc.Log.Entries, e)
+	}
+
+	payload, err := json.MarshalIndent(doc, "", "	")
+	if err != nil {
+		WriteFailed(hr.Out, err, "marshaling data")
+		return
+	}
+	if _, err = hr.Out.Write(payload); err != nil {
+		WriteFailed(hr.Out, err, "writing data")
+	}
+}
+
+func harTime(t time.Time) string {
+	return t.Format("2006-01-02T15:04:05.000Z07:00")
+}
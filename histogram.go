@@ -0,0 +1,181 @@
+package tracker
+
+import (
+	"math"
+	"math/bits"
+	"slices"
+	"sort"
+	"time"
+)
+
+// histogramBits is the number of significant bits of a recorded
+// duration, the quantiles are the middles of the buckets, so their
+// relative error is at most 1/2^(histogramBits+1), about 0.4%
+const histogramBits = 7
+
+// Histogram is a high dynamic range histogram of durations: the buckets
+// grow exponentially with 2^histogramBits linear sub-buckets each, so
+// memory stays small for any run length while quantiles keep two
+// significant digits. It is not safe for concurrent use, the histograms
+// returned by a track are copies.
+type Histogram struct {
+	Name     string
+	counts   []uint64
+	count    uint64
+	sum      time.Duration
+	min, max time.Duration
+}
+
+// bucket returns the index of the bucket of the value
+func bucket(v uint64) int {
+	if v < 1<<(histogramBits+1) {
+		return int(v)
+	}
+	shift := bits.Len64(v) - histogramBits - 1
+	return shift<<histogramBits + int(v>>shift)
+}
+
+// bucketValue returns the middle of the values of the bucket
+func bucketValue(i int) uint64 {
+	if i < 1<<(histogramBits+1) {
+		return uint64(i)
+	}
+	shift := i>>histogramBits - 1
+	low := uint64(i-shift<<histogramBits) << shift
+	return low + (1<<shift)/2
+}
+
+// Record adds the duration to the histogram, negative ones count as zero
+func (h *Histogram) Record(d time.Duration) {
+	d = max(d, 0)
+	i := bucket(uint64(d))
+	if i >= len(h.counts) {
+		h.counts = slices.Grow(h.counts, i+1-len(h.counts))[:i+1]
+	}
+	h.counts[i]++
+
+	if h.count == 0 || d < h.min {
+		h.min = d
+	}
+	h.max = max(h.max, d)
+	h.count++
+	h.sum += d
+}
+
+// Merge adds the durations recorded by o to the histogram
+func (h *Histogram) Merge(o *Histogram) {
+	if o.count == 0 {
+		return
+	}
+	if len(o.counts) > len(h.counts) {
+		h.counts = slices.Grow(h.counts, len(o.counts)-len(h.counts))[:len(o.counts)]
+	}
+	for i, c := range o.counts {
+		h.counts[i] += c
+	}
+
+	if h.count == 0 || o.min < h.min {
+		h.min = o.min
+	}
+	h.max = max(h.max, o.max)
+	h.count += o.count
+	h.sum += o.sum
+}
+
+func (h *Histogram) Count() uint64 {
+	return h.count
+}
+
+func (h *Histogram) Min() time.Duration {
+	return h.min
+}
+
+func (h *Histogram) Max() time.Duration {
+	return h.max
+}
+
+func (h *Histogram) Mean() time.Duration {
+	if h.count == 0 {
+		return 0
+	}
+	return h.sum / time.Duration(h.count)
+}
+
+// Quantile returns the q quantile of the recorded durations,
+// e.g. Quantile(0.999) for p999, within the range of the recorded ones
+func (h *Histogram) Quantile(q float64) time.Duration {
+	if h.count == 0 {
+		return 0
+	}
+	rank := uint64(math.Ceil(q * float64(h.count)))
+	rank = min(max(rank, 1), h.count)
+	if rank == h.count {
+		return h.max
+	}
+
+	var seen uint64
+	for i, c := range h.counts {
+		seen += c
+		if seen >= rank {
+			d := time.Duration(bucketValue(i))
+			return min(max(d, h.min), h.max)
+		}
+	}
+	return h.max
+}
+
+func (h *Histogram) clone() *Histogram {
+	c := *h
+	c.counts = slices.Clone(h.counts)
+	return &c
+}
+
+// SetHistograms makes t record the durations of every checkpoint name
+// into a Histogram on Update, the histograms see the checkpoints
+// dropped by the sampler too
+func (t *Track) SetHistograms(enabled bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !enabled {
+		t.histograms = nil
+		return
+	}
+	if t.histograms == nil {
+		t.histograms = make(map[string]*Histogram)
+	}
+}
+
+func (t *Track) record(m Meta) {
+	h, ok := t.histograms[m.Name]
+	if !ok {
+		h = &Histogram{Name: m.Name}
+		t.histograms[m.Name] = h
+	}
+	h.Record(m.Dur)
+}
+
+// Histogram returns a copy of the histogram of the checkpoints with the name
+func (t *Track) Histogram(name string) (*Histogram, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	h, ok := t.histograms[name]
+	if !ok {
+		return nil, false
+	}
+	return h.clone(), true
+}
+
+// Histograms returns copies of the histograms ordered by name
+func (t *Track) Histograms() []*Histogram {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.histograms == nil {
+		return nil
+	}
+	hs := make([]*Histogram, 0, len(t.histograms))
+	for _, h := range t.histograms {
+		hs = append(hs, h.clone())
+	}
+	sort.Slice(hs, func(i, j int) bool { return hs[i].Name < hs[j].Name })
+	return hs
+}
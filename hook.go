@@ -0,0 +1,41 @@
+package tracker
+
+import "context"
+
+// Hook records an operation of a client (a Redis command, a memcache
+// call...) into the track of the context as a checkpoint named
+// "system op" with the system attribute and the attrs given as key,
+// value pairs. Call it before the operation and the returned func with
+// the operation error after it:
+//
+//	done := tracker.Hook(ctx, "memcache", "get")
+//	item, err := mc.Get(key)
+//	done(err)
+//
+// It does nothing if the context carries no track.
+func Hook(ctx context.Context, system, op string, attrs ...string) func(error) {
+	t := FromContext(ctx)
+	if t == nil {
+		return func(error) {}
+	}
+
+	m := map[string]string{"system": system}
+	for i := 0; i+1 < len(attrs); i += 2 {
+		m[attrs[i]] = attrs[i+1]
+	}
+
+	start := t.now()
+	return func(err error) {
+		if ok, _ := t.begin(); !ok {
+			return
+		}
+		end := t.now()
+		t.insert(Meta{
+			Name:  system + " " + op,
+			Start: end,
+			Dur:   end.Sub(start),
+			Err:   err,
+			Attrs: m,
+		})
+	}
+}
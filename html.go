@@ -0,0 +1,404 @@
+package tracker
+
+import (
+	"cmp"
+	"html/template"
+	"io"
+	"maps"
+	"slices"
+	"time"
+)
+
+// HTMLRender writes a self-contained interactive HTML report: rows can be
+// sorted by any column and filtered by name, tag (the attributes, e.g.
+// "path=/etc") or error, and the timeline column can be zoomed. Checkpoints
+// spanning others, e.g. a Step around some Updates, are shown as their
+// parents and can be collapsed. Everything runs client side, no server is
+// required.
+//
+// The columns are the ones of the table chosen by Options, the timeline is
+// shown too when the track is not configured, and MaxRows sums up the rows
+// in between the same way.
+//
+// Colors and fonts are CSS variables (--bg, --fg, --muted, --border,
+// --bar, --error, --font), they follow the system dark/light preference
+// unless Theme forces one. Stylesheet is appended after the built-in
+// styles, so it can override the variables or any rule to match
+// internal branding.
+//
+// When SourceContext is set and the checkpoints have call sites, every
+// row can be expanded to that many lines of source around the call site,
+// read at render time.
+type HTMLRender struct {
+	Out   io.Writer
+	Title string
+	// Theme is "light", "dark" or empty to follow the system preference
+	Theme         string
+	Stylesheet    template.CSS
+	SourceContext int
+	// Locale is the BCP 47 language tag of the number formatting, e.g.
+	// "de-DE", the browser separates the thousands and the decimals in
+	// the locale. Numbers are formatted plainly if it is empty.
+	Locale string
+}
+
+type htmlRow struct {
+	Index int          `json:"index"`
+	Seq   uint64       `json:"seq"`
+	ID    string       `json:"id"`
+	Name  string       `json:"name"`
+	Tags  []string     `json:"tags,omitempty"`
+	Start int64        `json:"start"`
+	Dur   int64        `json:"dur"`
+	Busy  int64        `json:"busy"`
+	Since int64        `json:"since"`
+	Err   string       `json:"err"`
+	Link  string       `json:"link"`
+	Src   []SourceLine `json:"src,omitempty"`
+	// More is the number of the rows summed up by the row, see MaxRows
+	More int `json:"more,omitempty"`
+	// Parent is the position of the parent row, -1 for the top level
+	Parent int `json:"parent"`
+	Depth  int `json:"depth"`
+	Kids   int `json:"kids,omitempty"`
+}
+
+type htmlReport struct {
+	Title      string
+	Theme      string
+	Stylesheet template.CSS
+	Locale     string
+	Total      int64
+	Columns    []string
+	Timeline   bool
+	Rows       []htmlRow
+}
+
+func (hr HTMLRender) Render(data MetaData, opt *Options) {
+	if opt == nil {
+		opt = defaultOptions()
+		opt.withTrack = true
+	}
+	report := htmlReport{
+		Title:      hr.Title,
+		Theme:      hr.Theme,
+		Stylesheet: hr.Stylesheet,
+		Locale:     hr.Locale,
+		Columns:    createHeaders(nil, opt),
+		Timeline:   opt.withTrack,
+	}
+	if report.Title == "" {
+		report.Title = "tracker report"
+	}
+
+	src := newSourceReader(hr.SourceContext)
+	overflow := newOverflow(data, opt, sameFormats(time.Duration.String))
+	rows := make([]htmlRow, 0, len(data))
+	for i := 0; i < len(data); {
+		if !overflow.hides(i) {
+			rows = append(rows, htmlRowOf(i, data[i], src))
+			i++
+			continue
+		}
+		more := htmlRow{Index: i, Start: int64(data[i].StartDif - data[i].Dur)}
+		for ; i < len(data) && overflow.hides(i); i++ {
+			more.Dur += int64(data[i].Dur)
+			more.More++
+		}
+		more.Since = int64(data[i-1].StartDif)
+		rows = append(rows, more)
+	}
+	for _, row := range rows {
+		report.Total = max(report.Total, row.Since)
+	}
+	report.Rows = nest(rows)
+
+	if err := htmlTemplate.Execute(hr.Out, report); err != nil {
+		WriteFailed(hr.Out, err, "writing report")
+	}
+}
+
+func htmlRowOf(i int, m Meta, src *sourceReader) htmlRow {
+	row := htmlRow{
+		Index: i,
+		Seq:   m.Seq,
+		ID:    m.ID,
+		Name:  m.Name,
+		Start: int64(m.StartDif - m.Dur),
+		Dur:   int64(m.Dur),
+		Busy:  int64(m.Busy()),
+		Since: int64(m.StartDif),
+		Link:  m.Link(),
+		Src:   src.context(m),
+	}
+	for _, k := range slices.Sorted(maps.Keys(m.Attrs)) {
+		row.Tags = append(row.Tags, k+"="+m.Attrs[k])
+	}
+	if m.Err != nil {
+		row.Err = m.Err.Error()
+	}
+	return row
+}
+
+// nest orders the rows as a tree: the parent of a row is the shortest
+// row spanning it, e.g. a Step around the Updates made in it, and the
+// children follow their parent. Rows of zero duration and the summed up
+// rows never have children.
+func nest(rows []htmlRow) []htmlRow {
+	slices.SortStableFunc(rows, func(a, b htmlRow) int {
+		return cmp.Or(
+			cmp.Compare(a.Start, b.Start),
+			// an instant is not inside a span starting at it
+			cmp.Compare(min(a.Dur, 1), min(b.Dur, 1)),
+			cmp.Compare(b.Since, a.Since),
+		)
+	})
+
+	var spans []int
+	for i := range rows {
+		r := &rows[i]
+		for len(spans) > 0 {
+			top := rows[spans[len(spans)-1]]
+			if top.Since > r.Since || top.Since == r.Since && r.Dur > 0 {
+				break
+			}
+			spans = spans[:len(spans)-1]
+		}
+
+		r.Parent = -1
+		if len(spans) > 0 {
+			r.Parent = spans[len(spans)-1]
+			if rows[r.Parent].Dur == r.Dur {
+				// the same span twice, the rows are siblings
+				r.Parent = rows[r.Parent].Parent
+			}
+		}
+		if r.Parent >= 0 {
+			r.Depth = rows[r.Parent].Depth + 1
+			rows[r.Parent].Kids++
+		}
+		if r.Dur > 0 && r.More == 0 {
+			spans = append(spans, i)
+		}
+	}
+	return rows
+}
+
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html{{if .Theme}} data-theme="{{.Theme}}"{{end}}>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+:root { --bg: #fff; --fg: #222; --muted: #666; --border: #ddd; --bar: #4a90d9; --error: #c00; --font: sans-serif; }
+:root[data-theme="dark"] { --bg: #1e1e1e; --fg: #ddd; --muted: #999; --border: #444; --bar: #5b9bd5; --error: #f66; }
+@media (prefers-color-scheme: dark) {
+	:root:not([data-theme="light"]) { --bg: #1e1e1e; --fg: #ddd; --muted: #999; --border: #444; --bar: #5b9bd5; --error: #f66; }
+}
+body { background: var(--bg); color: var(--fg); font-family: var(--font); margin: 1em; }
+input { background: var(--bg); color: var(--fg); border: 1px solid var(--border); }
+table { border-collapse: collapse; width: 100%; }
+th, td { border-bottom: 1px solid var(--border); padding: 2px 6px; text-align: left; white-space: nowrap; }
+th { color: var(--muted); cursor: pointer; user-select: none; }
+td.num { text-align: right; font-variant-numeric: tabular-nums; }
+td.err { color: var(--error); }
+td.line { width: 100%; }
+.lane { overflow-x: auto; }
+.axis { position: relative; height: 12px; }
+.bar { position: absolute; top: 1px; height: 10px; min-width: 1px; background: var(--bar); }
+.bar.failed { background: var(--error); }
+.controls { margin-bottom: .5em; }
+summary { cursor: pointer; }
+.toggle { cursor: pointer; user-select: none; }
+.tag { display: inline-block; margin-left: 4px; padding: 0 4px; border: 1px solid var(--border); border-radius: 3px; color: var(--muted); font-size: smaller; cursor: pointer; }
+td.more { color: var(--muted); font-style: italic; }
+pre { margin: 0; color: var(--muted); }
+pre .current { color: var(--fg); font-weight: bold; }
+</style>{{if .Stylesheet}}
+<style>
+{{.Stylesheet}}
+</style>{{end}}
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<div class="controls">
+<input id="filter" type="search" placeholder="filter by name, tag or error">{{if .Timeline}}
+<label>zoom <input id="zoom" type="range" min="1" max="50" value="1"></label>{{end}}
+</div>
+<table>
+<thead><tr id="headers"></tr></thead>
+<tbody id="rows"></tbody>
+</table>
+<script>
+(function() {
+	var total = {{.Total}} || 1;
+	var columns = {{.Columns}};
+	var rows = {{.Rows}};
+	var locale = {{.Locale}};
+	rows.forEach(function(r, i) {
+		r.order = i;
+		if (r.more) r.name = "… " + num(r.more, 0) + " more checkpoints";
+	});
+
+	// the sort keys and titles of the columns, see Options
+	var keys = {"seq": "seq", "id": "id", "func.name": "name", "since.start": "since",
+		"duration": "dur", "busy": "busy", "errors": "err", "link": "link"};
+	var titles = {"func.name": "name", "since.start": "since start", "errors": "error", "track": "timeline"};
+
+	var filter = document.getElementById("filter");
+	var sortKey = "order", sortAsc = true, zoom = 1, collapsed = {};
+
+	function num(x, digits) {
+		if (!locale) return digits ? x.toFixed(digits) : String(x);
+		return x.toLocaleString(locale, {minimumFractionDigits: digits, maximumFractionDigits: digits});
+	}
+
+	function fmt(ns) {
+		if (ns >= 1e9) return num(ns / 1e9, 3) + "s";
+		if (ns >= 1e6) return num(ns / 1e6, 3) + "ms";
+		if (ns >= 1e3) return num(ns / 1e3, 3) + "µs";
+		return num(ns, 0) + "ns";
+	}
+
+	function cell(tr, text, cls) {
+		var td = document.createElement("td");
+		td.textContent = text;
+		if (cls) td.className = cls;
+		tr.appendChild(td);
+		return td;
+	}
+
+	function matches(r, q) {
+		if (!q) return true;
+		var text = [r.name, r.id, r.err].concat(r.tags || []).join("\n");
+		return text.toLowerCase().indexOf(q) >= 0;
+	}
+
+	function hidden(r) {
+		for (var p = r.parent; p >= 0; p = rows[p].parent) {
+			if (collapsed[p]) return true;
+		}
+		return false;
+	}
+
+	function index(tr, r) {
+		var td = cell(tr, "", "num");
+		if (r.kids) {
+			var toggle = document.createElement("span");
+			toggle.className = "toggle";
+			toggle.textContent = collapsed[r.order] ? "▸ " : "▾ ";
+			toggle.title = num(r.kids, 0) + " child spans";
+			toggle.addEventListener("click", function() {
+				collapsed[r.order] = !collapsed[r.order];
+				draw();
+			});
+			td.appendChild(toggle);
+		}
+		if (!r.more) td.appendChild(document.createTextNode(num(r.index, 0)));
+	}
+
+	function name(tr, r) {
+		var td = cell(tr, r.src ? "" : r.name, r.more ? "more" : "");
+		td.style.paddingLeft = (6 + 16 * r.depth) + "px";
+		if (r.src) {
+			var details = document.createElement("details");
+			var summary = document.createElement("summary");
+			summary.textContent = r.name;
+			summary.title = r.link;
+			var pre = document.createElement("pre");
+			r.src.forEach(function(l) {
+				var line = document.createElement("span");
+				line.textContent = ("     " + l.n).slice(-5) + "  " + l.text + "\n";
+				if (l.current) line.className = "current";
+				pre.appendChild(line);
+			});
+			details.appendChild(summary);
+			details.appendChild(pre);
+			td.appendChild(details);
+		}
+		(r.tags || []).forEach(function(t) {
+			var tag = document.createElement("span");
+			tag.className = "tag";
+			tag.textContent = t;
+			tag.addEventListener("click", function() {
+				filter.value = t;
+				draw();
+			});
+			td.appendChild(tag);
+		});
+	}
+
+	function timeline(tr, r) {
+		var lane = cell(tr, "", "line lane");
+		var axis = document.createElement("div");
+		axis.className = "axis";
+		axis.style.width = (zoom * 100) + "%";
+		var bar = document.createElement("div");
+		bar.className = r.err ? "bar failed" : "bar";
+		bar.style.left = (r.start / total * 100) + "%";
+		bar.style.width = (r.dur / total * 100) + "%";
+		bar.title = r.name + ": " + fmt(r.dur);
+		axis.appendChild(bar);
+		lane.appendChild(axis);
+	}
+
+	function draw() {
+		var q = filter.value.toLowerCase();
+		var body = document.getElementById("rows");
+		body.textContent = "";
+
+		rows.filter(function(r) {
+			return matches(r, q) && !hidden(r);
+		}).sort(function(a, b) {
+			var x = a[sortKey], y = b[sortKey];
+			var c = x < y ? -1 : x > y ? 1 : 0;
+			return sortAsc ? c : -c;
+		}).forEach(function(r) {
+			var tr = document.createElement("tr");
+			index(tr, r);
+			columns.forEach(function(c) {
+				switch (c) {
+				case "seq": cell(tr, r.more ? "" : num(r.seq, 0), "num"); break;
+				case "id": cell(tr, r.id); break;
+				case "func.name": name(tr, r); break;
+				case "since.start": cell(tr, fmt(r.since), "num"); break;
+				case "duration": cell(tr, fmt(r.dur), "num"); break;
+				case "busy": cell(tr, fmt(r.busy), "num"); break;
+				case "errors": cell(tr, r.err, "err"); break;
+				case "track": timeline(tr, r); break;
+				case "link": cell(tr, r.link); break;
+				}
+			});
+			body.appendChild(tr);
+		});
+	}
+
+	var headers = document.getElementById("headers");
+	["#"].concat(columns).forEach(function(c) {
+		var th = document.createElement("th");
+		th.textContent = titles[c] || c;
+		var key = c === "#" ? "order" : keys[c];
+		if (key) {
+			th.addEventListener("click", function() {
+				sortAsc = key === sortKey ? !sortAsc : true;
+				sortKey = key;
+				draw();
+			});
+		}
+		headers.appendChild(th);
+	});
+	filter.addEventListener("input", draw);
+	var slider = document.getElementById("zoom");
+	if (slider) {
+		slider.addEventListener("input", function(e) {
+			zoom = +e.target.value;
+			draw();
+		});
+	}
+
+	draw();
+})();
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,194 @@
+package tracker
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Catalog holds the words of durations in a language for Humanize
+type Catalog struct {
+	// the forms of the unit words, indexed by Plural
+	Hour, Minute, Second, Millisecond, Microsecond, Nanosecond []string
+	// Plural returns the index of the form of the unit word for n
+	Plural func(n int64) int
+	// Separator goes between the units, a space if empty
+	Separator string
+}
+
+func pluralOne(n int64) int {
+	if n == 1 {
+		return 0
+	}
+	return 1
+}
+
+// english is the catalog of the words missing in the other ones
+var english = &Catalog{
+	Hour:        []string{"hour", "hours"},
+	Minute:      []string{"minute", "minutes"},
+	Second:      []string{"second", "seconds"},
+	Millisecond: []string{"millisecond", "milliseconds"},
+	Microsecond: []string{"microsecond", "microseconds"},
+	Nanosecond:  []string{"nanosecond", "nanoseconds"},
+	Plural:      pluralOne,
+}
+
+var (
+	// guards catalogs and numberFormats
+	langMu sync.RWMutex
+
+	// the catalogs by language, see RegisterCatalog
+	catalogs = map[string]*Catalog{
+		"en": english,
+		"de": {
+			Hour:        []string{"Stunde", "Stunden"},
+			Minute:      []string{"Minute", "Minuten"},
+			Second:      []string{"Sekunde", "Sekunden"},
+			Millisecond: []string{"Millisekunde", "Millisekunden"},
+			Microsecond: []string{"Mikrosekunde", "Mikrosekunden"},
+			Nanosecond:  []string{"Nanosekunde", "Nanosekunden"},
+			Plural:      pluralOne,
+		},
+		"es": {
+			Hour:        []string{"hora", "horas"},
+			Minute:      []string{"minuto", "minutos"},
+			Second:      []string{"segundo", "segundos"},
+			Millisecond: []string{"milisegundo", "milisegundos"},
+			Microsecond: []string{"microsegundo", "microsegundos"},
+			Nanosecond:  []string{"nanosegundo", "nanosegundos"},
+			Plural:      pluralOne,
+		},
+		"fr": {
+			Hour:        []string{"heure", "heures"},
+			Minute:      []string{"minute", "minutes"},
+			Second:      []string{"seconde", "secondes"},
+			Millisecond: []string{"milliseconde", "millisecondes"},
+			Microsecond: []string{"microseconde", "microsecondes"},
+			Nanosecond:  []string{"nanoseconde", "nanosecondes"},
+			Plural: func(n int64) int {
+				if n <= 1 {
+					return 0
+				}
+				return 1
+			},
+		},
+		"ru": {
+			Hour:        []string{"час", "часа", "часов"},
+			Minute:      []string{"минута", "минуты", "минут"},
+			Second:      []string{"секунда", "секунды", "секунд"},
+			Millisecond: []string{"миллисекунда", "миллисекунды", "миллисекунд"},
+			Microsecond: []string{"микросекунда", "микросекунды", "микросекунд"},
+			Nanosecond:  []string{"наносекунда", "наносекунды", "наносекунд"},
+			Plural: func(n int64) int {
+				switch {
+				case n%10 == 1 && n%100 != 11:
+					return 0
+				case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+					return 1
+				}
+				return 2
+			},
+		},
+	}
+)
+
+var errInvalidCatalog = errors.New("catalog needs Plural and the forms of every unit")
+
+// RegisterCatalog adds the catalog of the language or replaces it, it is
+// safe to call while rendering. The catalog must have Plural and at least
+// one form of every unit.
+func RegisterCatalog(lang string, c *Catalog) error {
+	if c == nil || c.Plural == nil {
+		return errInvalidCatalog
+	}
+	for _, forms := range [][]string{c.Hour, c.Minute, c.Second, c.Millisecond, c.Microsecond, c.Nanosecond} {
+		if len(forms) == 0 {
+			return errInvalidCatalog
+		}
+	}
+
+	langMu.Lock()
+	defer langMu.Unlock()
+	catalogs[lang] = c
+	return nil
+}
+
+// LookupCatalog returns the catalog of the language tag, e.g. "de-AT"
+// falls back to "de", and an unknown language to English
+func LookupCatalog(lang string) *Catalog {
+	return lookupLang(catalogs, lang)
+}
+
+// Humanize returns the duration in English words, e.g. "1 minute 4 seconds"
+func Humanize(d time.Duration) string {
+	return english.Humanize(d)
+}
+
+// Humanize returns the duration in words of the two largest units,
+// e.g. "1 minute 4 seconds" or "250 milliseconds"
+func (c *Catalog) Humanize(d time.Duration) string {
+	units := []struct {
+		d         time.Duration
+		words, en []string
+	}{
+		{time.Hour, c.Hour, english.Hour},
+		{time.Minute, c.Minute, english.Minute},
+		{time.Second, c.Second, english.Second},
+		{time.Millisecond, c.Millisecond, english.Millisecond},
+		{time.Microsecond, c.Microsecond, english.Microsecond},
+		{time.Nanosecond, c.Nanosecond, english.Nanosecond},
+	}
+	sep := c.Separator
+	if sep == "" {
+		sep = " "
+	}
+
+	var b strings.Builder
+	if d < 0 {
+		b.WriteByte('-')
+		d = -d
+	}
+	if d == 0 {
+		b.WriteString("0 ")
+		b.WriteString(c.word(c.Second, english.Second, 0))
+		return b.String()
+	}
+
+	for i, u := range units {
+		if d < u.d {
+			continue
+		}
+		n := int64(d / u.d)
+		b.WriteString(strconv.FormatInt(n, 10))
+		b.WriteByte(' ')
+		b.WriteString(c.word(u.words, u.en, n))
+
+		if i+1 < len(units) {
+			next := units[i+1]
+			if m := int64(d % u.d / next.d); m > 0 {
+				b.WriteString(sep)
+				b.WriteString(strconv.FormatInt(m, 10))
+				b.WriteByte(' ')
+				b.WriteString(c.word(next.words, next.en, m))
+			}
+		}
+		break
+	}
+	return b.String()
+}
+
+// word returns the form of the unit word for n, the English one
+// if the catalog lacks the forms of the unit or Plural
+func (c *Catalog) word(forms, en []string, n int64) string {
+	if len(forms) == 0 || c.Plural == nil {
+		return english.word(en, nil, n)
+	}
+	i := c.Plural(n)
+	if i < 0 || i >= len(forms) {
+		i = len(forms) - 1
+	}
+	return forms[i]
+}
@@ -0,0 +1,50 @@
+package tracker
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// hyperlink returns the text as an OSC 8 terminal hyperlink to the call
+// site of the checkpoint by LinkTemplate
+func (ro *RenderOptions) hyperlink(text string, m Meta) string {
+	url := strings.NewReplacer(
+		"{path}", m.File,
+		"{line}", strconv.Itoa(m.Line),
+	).Replace(ro.LinkTemplate)
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// hyperlinks returns the replacer of the links of the checkpoints with
+// their hyperlinks, or nil if there is no LinkTemplate. The table is laid
+// out with the plain links and replaced then, as the escape sequences
+// take no room on the screen.
+func (ro *RenderOptions) hyperlinks(data MetaData, opt *Options) *strings.Replacer {
+	if ro.LinkTemplate == "" || !opt.withLink {
+		return nil
+	}
+
+	links := make(map[string]string)
+	for _, m := range data {
+		if link := m.Link(); link != "" {
+			links[link] = ro.hyperlink(link, m)
+		}
+	}
+	if len(links) == 0 {
+		return nil
+	}
+
+	// the longer links go first, so main.go:1 does not match main.go:12
+	keys := make([]string, 0, len(links))
+	for k := range links {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return len(keys[i]) > len(keys[j]) })
+
+	pairs := make([]string, 0, 2*len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k, links[k])
+	}
+	return strings.NewReplacer(pairs...)
+}
@@ -0,0 +1,64 @@
+package tracker
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Ignore lists the functions whose checkpoints are dropped on Update,
+// e.g. noisy helpers. Their time is accounted for in the next checkpoint.
+type Ignore struct {
+	// Names are the exact function names like "main.retry"
+	Names    []string
+	Prefixes []string
+	Patterns []*regexp.Regexp
+}
+
+// ignore is Ignore prepared for matching
+type ignore struct {
+	names    map[string]struct{}
+	prefixes []string
+	patterns []*regexp.Regexp
+}
+
+func newIgnore(ig Ignore) *ignore {
+	if len(ig.Names) == 0 && len(ig.Prefixes) == 0 && len(ig.Patterns) == 0 {
+		return nil
+	}
+	i := &ignore{
+		names:    make(map[string]struct{}, len(ig.Names)),
+		prefixes: append([]string(nil), ig.Prefixes...),
+		patterns: append([]*regexp.Regexp(nil), ig.Patterns...),
+	}
+	for _, n := range ig.Names {
+		i.names[n] = struct{}{}
+	}
+	return i
+}
+
+func (i *ignore) match(name string) bool {
+	if i == nil {
+		return false
+	}
+	if _, ok := i.names[name]; ok {
+		return true
+	}
+	for _, p := range i.prefixes {
+		if strings.HasPrefix(name, p) {
+			return true
+		}
+	}
+	for _, re := range i.patterns {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetIgnore makes t drop the checkpoints made in the ignored functions
+func (t *Track) SetIgnore(ig Ignore) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.ignore = newIgnore(ig)
+}
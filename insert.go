@@ -0,0 +1,12 @@
+package tracker
+
+// Insert appends a checkpoint measured outside of the track, e.g. a span
+// of a tracing system: its Start is the end of the step and Dur is the
+// duration of the step. Unlike Record it resolves no caller, so the
+// checkpoint has no file and line. It starts a zero-value track like
+// Update and is safe to call while Update records.
+func (t *Track) Insert(meta Meta) {
+	if ok, _ := t.begin(); ok {
+		t.insert(meta)
+	}
+}
@@ -0,0 +1,68 @@
+package tracker
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+// dropNamed is a Sampler dropping the checkpoints with the name
+type dropNamed string
+
+func (d dropNamed) Sample(m Meta) bool { return m.Name != string(d) }
+
+func TestInsertZeroValue(t *testing.T) {
+	var tr Track
+	clock := NewSimClock(simStart, 0)
+	if err := tr.SetClock(clock); err != nil {
+		t.Fatal(err)
+	}
+
+	clock.Advance(time.Second)
+	tr.Insert(Meta{Name: "span", Start: simStart.Add(time.Second), Dur: time.Second})
+
+	data := tr.Snapshot()
+	if len(data) != 2 {
+		t.Fatalf("got %d checkpoints, want the creation and the span", len(data))
+	}
+	m := data[1]
+	if m.Name != "span" || m.File != "" || m.Line != 0 {
+		t.Errorf("got %s at %s:%d, want the span without a location", m.Name, m.File, m.Line)
+	}
+}
+
+func TestRecordFilters(t *testing.T) {
+	tr, clock := simTrack(t)
+	tr.SetIgnore(Ignore{Names: []string{"noise"}})
+	tr.SetRewrites(Rewrite{Pattern: regexp.MustCompile(`^query-\d+$`), Replacement: "query"})
+	tr.SetSampler(dropNamed("sampled"))
+	tr.SetAverages(1)
+	tr.SetHistograms(true)
+
+	clock.Advance(time.Second)
+	tr.Update(nil)
+	tr.Record("noise", simStart, time.Millisecond, nil)
+	tr.Record("query-1", simStart, 2*time.Millisecond, nil)
+	tr.Record("sampled", simStart, 3*time.Millisecond, nil)
+
+	data := tr.Snapshot()
+	if len(data) != 3 || data[2].Name != "query" {
+		t.Fatalf("got %d checkpoints, want the creation, the update and the rewritten query", len(data))
+	}
+	if _, ok := tr.Average("query"); !ok {
+		t.Error("no average of the query")
+	}
+	if _, ok := tr.Histogram("sampled"); !ok {
+		t.Error("no histogram of the sampled checkpoint")
+	}
+	if _, ok := tr.Histogram("noise"); ok {
+		t.Error("histogram of the ignored checkpoint")
+	}
+
+	// the recorded checkpoints do not move the base of Update
+	clock.Advance(time.Second)
+	tr.Update(nil)
+	if got := last(t, tr).Dur; got != time.Second {
+		t.Errorf("got Update duration %v, want 1s", got)
+	}
+}
@@ -0,0 +1,127 @@
+package tracker
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"time"
+)
+
+// All returns an iterator over the checkpoints of t with their indexes.
+// It reads the storage directly (chunked or not) without copying it whole,
+// the track is locked only while a single checkpoint is copied, so the loop
+// body may record new checkpoints, which are then visited too.
+func (t *Track) All() iter.Seq2[int, Meta] {
+	return func(yield func(int, Meta) bool) {
+		for i := 0; ; i++ {
+			t.mu.Lock()
+			if i >= t.len() {
+				t.mu.Unlock()
+				return
+			}
+			m := t.at(i).clone()
+			t.mu.Unlock()
+
+			if !yield(i, m) {
+				return
+			}
+		}
+	}
+}
+
+// Iter returns an iterator over the checkpoints with their indexes
+func (m MetaData) Iter() iter.Seq2[int, Meta] {
+	return func(yield func(int, Meta) bool) {
+		for i, e := range m {
+			if !yield(i, e) {
+				return
+			}
+		}
+	}
+}
+
+// Len returns the number of the checkpoints of t
+func (t *Track) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.len()
+}
+
+// Get returns a copy of the i-th checkpoint of t,
+// false if there is no such checkpoint
+func (t *Track) Get(i int) (Meta, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if i < 0 || i >= t.len() {
+		return Meta{}, false
+	}
+	return t.at(i).clone(), true
+}
+
+// Snapshot returns a copy of the checkpoints of t,
+// changes of the copy do not affect the track
+func (t *Track) Snapshot() MetaData {
+	data := t.snapshot()
+	for i := range data {
+		data[i] = data[i].clone()
+	}
+	return data
+}
+
+// clone returns a copy of m which shares no attributes and notes with it
+func (m Meta) clone() Meta {
+	m.Attrs = maps.Clone(m.Attrs)
+	m.Notes = slices.Clone(m.Notes)
+	return m
+}
+
+// First returns the first checkpoint, false if there are none
+func (m MetaData) First() (Meta, bool) {
+	if len(m) == 0 {
+		return Meta{}, false
+	}
+	return m[0], true
+}
+
+// Last returns the last checkpoint, false if there are none
+func (m MetaData) Last() (Meta, bool) {
+	if len(m) == 0 {
+		return Meta{}, false
+	}
+	return m[len(m)-1], true
+}
+
+// steps returns the checkpoints without the creation of the track,
+// nil if there are none, so empty and zero-value tracks render too
+func steps(data MetaData) MetaData {
+	if len(data) < 2 {
+		return nil
+	}
+	return data[1:]
+}
+
+// ByNameFirst returns the first checkpoint with the name,
+// false if there is no such checkpoint
+func (m MetaData) ByNameFirst(name string) (Meta, bool) {
+	for _, e := range m {
+		if e.Name == name {
+			return e, true
+		}
+	}
+	return Meta{}, false
+}
+
+// Duration returns the duration of the first checkpoint of t with
+// the name, false if there is no such checkpoint
+func (t *Track) Duration(name string) (time.Duration, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i := 0; i < t.len(); i++ {
+		if m := t.at(i); m.Name == name {
+			return m.Dur, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,156 @@
+package tracker
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// defaultJobRuns is the number of runs a job registry keeps per job
+const defaultJobRuns = 20
+
+// JobRun is a single run of a scheduled job
+type JobRun struct {
+	Start time.Time     `json:"start"`
+	Dur   time.Duration `json:"duration"`
+	OK    bool          `json:"ok"`
+}
+
+// Jobs is a registry of the runs of repeatedly run named tracks, e.g.
+// cron jobs, it keeps the last runs of every job. It is safe for
+// concurrent use.
+type Jobs struct {
+	mu   sync.Mutex
+	keep int
+	runs map[string][]JobRun
+}
+
+// NewJobs returns a registry keeping the last runs of every job,
+// 20 runs if runs is not positive
+func NewJobs(runs int) *Jobs {
+	if runs <= 0 {
+		runs = defaultJobRuns
+	}
+	return &Jobs{keep: runs, runs: make(map[string][]JobRun)}
+}
+
+// Add registers the track as a run of the job, the run failed
+// if any checkpoint of the track has an error
+func (j *Jobs) Add(job string, t *Track) {
+	data := t.snapshot()
+	if len(data) == 0 {
+		return
+	}
+
+	run := JobRun{Start: data[0].Start, Dur: data[len(data)-1].StartDif, OK: true}
+	for _, m := range data {
+		if m.Err != nil {
+			run.OK = false
+			break
+		}
+	}
+	j.AddRun(job, run)
+}
+
+// AddRun registers a run of the job
+func (j *Jobs) AddRun(job string, run JobRun) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	runs := append(j.runs[job], run)
+	if len(runs) > j.keep {
+		runs = append(runs[:0], runs[len(runs)-j.keep:]...)
+	}
+	j.runs[job] = runs
+}
+
+// JobHealth is the health summary of a job over its kept runs
+type JobHealth struct {
+	Name    string        `json:"name"`
+	Runs    []JobRun      `json:"runs"`
+	LastRun time.Time     `json:"last_run"`
+	Mean    time.Duration `json:"mean"`
+	// Streak is the number of successful runs since the last failure
+	Streak int `json:"streak"`
+}
+
+// Health returns the summaries of the jobs ordered by name
+func (j *Jobs) Health() []JobHealth {
+	j.mu.Lock()
+	health := make([]JobHealth, 0, len(j.runs))
+	for name, runs := range j.runs {
+		health = append(health, JobHealth{Name: name, Runs: append([]JobRun(nil), runs...)})
+	}
+	j.mu.Unlock()
+
+	for i := range health {
+		h := &health[i]
+		var total time.Duration
+		for _, r := range h.Runs {
+			total += r.Dur
+			if r.Start.After(h.LastRun) {
+				h.LastRun = r.Start
+			}
+		}
+		h.Mean = total / time.Duration(len(h.Runs))
+		for k := len(h.Runs) - 1; k >= 0 && h.Runs[k].OK; k-- {
+			h.Streak++
+		}
+	}
+
+	sort.Slice(health, func(a, b int) bool { return health[a].Name < health[b].Name })
+	return health
+}
+
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline returns the durations of the runs as a line of bars, the
+// failed runs are drawn as "x"
+func (h JobHealth) Sparkline() string {
+	var min, max time.Duration
+	for i, r := range h.Runs {
+		if i == 0 || r.Dur < min {
+			min = r.Dur
+		}
+		if r.Dur > max {
+			max = r.Dur
+		}
+	}
+
+	line := make([]rune, len(h.Runs))
+	for i, r := range h.Runs {
+		switch {
+		case !r.OK:
+			line[i] = 'x'
+		case max == min:
+			line[i] = sparks[0]
+		default:
+			line[i] = sparks[int(r.Dur-min)*(len(sparks)-1)/int(max-min)]
+		}
+	}
+	return string(line)
+}
+
+// RenderJobs renders the health of the jobs as a table
+func (tbr TableRender) RenderJobs(j *Jobs) {
+	out := capture(tbr.Out)
+	table := tablewriter.NewWriter(out)
+	table.SetHeader([]string{"job", "last run", "mean", "streak", "runs"})
+
+	for _, h := range j.Health() {
+		table.Append([]string{
+			h.Name,
+			h.LastRun.Format(time.DateTime),
+			round(h.Mean).String(),
+			tbr.count(int64(h.Streak)),
+			h.Sparkline(),
+		})
+	}
+
+	table.Render()
+	if err := out.Err(); err != nil {
+		WriteFailed(tbr.Out, err, "writing data")
+	}
+}
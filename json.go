@@ -0,0 +1,161 @@
+package tracker
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// appendMeta appends m encoded the same way as json.MarshalIndent(m, "\t", "\t")
+// does, but without reflection, it is the fast path of JSONRender. If unit
+// is set the durations are numbers of the unit, see RenderOptions.NumericUnit.
+func appendMeta(b []byte, m Meta, unit time.Duration) ([]byte, error) {
+	var suffix string
+	if unit > 0 {
+		suffix = "_" + unitName(unit)
+	}
+
+	b = append(b, "{\n\t\t\"name\": "...)
+	b = appendString(b, m.Name)
+	b = append(b, ",\n\t\t\"start\": \""...)
+	b = m.Start.AppendFormat(b, time.RFC3339Nano)
+	b = append(b, "\",\n\t\t\"dur"+suffix+"\": "...)
+	b = appendNumeric(b, m.Dur, unit)
+	b = append(b, ",\n\t\t\"start_dif"+suffix+"\": "...)
+	b = appendNumeric(b, m.StartDif, unit)
+	b = append(b, ",\n\t\t\"error\": "...)
+	if m.Err == nil {
+		b = append(b, "null"...)
+	} else {
+		payload, err := json.Marshal(m.Err)
+		if err != nil {
+			return b, err
+		}
+		var buf bytes.Buffer
+		if err = json.Indent(&buf, payload, "\t\t", "\t"); err != nil {
+			return b, err
+		}
+		b = append(b, buf.Bytes()...)
+	}
+	if m.File != "" {
+		b = append(b, ",\n\t\t\"file\": "...)
+		b = appendString(b, m.File)
+	}
+	if m.Line != 0 {
+		b = append(b, ",\n\t\t\"line\": "...)
+		b = strconv.AppendInt(b, int64(m.Line), 10)
+	}
+	if m.Pkg != "" {
+		b = append(b, ",\n\t\t\"pkg\": "...)
+		b = appendString(b, m.Pkg)
+	}
+	b = append(b, ",\n\t\t\"seq\": "...)
+	b = strconv.AppendUint(b, m.Seq, 10)
+	if m.ID != "" {
+		b = append(b, ",\n\t\t\"id\": "...)
+		b = appendString(b, m.ID)
+	}
+	if m.Blocked != 0 {
+		b = append(b, ",\n\t\t\"blocked"+suffix+"\": "...)
+		b = appendNumeric(b, m.Blocked, unit)
+	}
+	if len(m.Attrs) > 0 {
+		keys := make([]string, 0, len(m.Attrs))
+		for k := range m.Attrs {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		b = append(b, ",\n\t\t\"attrs\": {"...)
+		for i, k := range keys {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = append(b, "\n\t\t\t"...)
+			b = appendString(b, k)
+			b = append(b, ": "...)
+			b = appendString(b, m.Attrs[k])
+		}
+		b = append(b, "\n\t\t}"...)
+	}
+	if len(m.Notes) > 0 {
+		b = append(b, ",\n\t\t\"notes\": ["...)
+		for i, n := range m.Notes {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = append(b, "\n\t\t\t"...)
+			b = appendString(b, n)
+		}
+		b = append(b, "\n\t\t]"...)
+	}
+	if m.Phase != "" {
+		b = append(b, ",\n\t\t\"phase\": "...)
+		b = appendString(b, m.Phase)
+	}
+	if m.Simulated {
+		b = append(b, ",\n\t\t\"simulated\": true"...)
+	}
+	if m.Pinned {
+		b = append(b, ",\n\t\t\"pinned\": true"...)
+	}
+	return append(b, "\n\t}"...), nil
+}
+
+const hex = "0123456789abcdef"
+
+// appendString appends s as a JSON string escaped the same way as encoding/json does
+func appendString(b []byte, s string) []byte {
+	b = append(b, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' && c != '<' && c != '>' && c != '&' {
+				i++
+				continue
+			}
+			b = append(b, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				b = append(b, '\\', c)
+			case '\b':
+				b = append(b, '\\', 'b')
+			case '\f':
+				b = append(b, '\\', 'f')
+			case '\n':
+				b = append(b, '\\', 'n')
+			case '\r':
+				b = append(b, '\\', 'r')
+			case '\t':
+				b = append(b, '\\', 't')
+			default:
+				b = append(b, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			b = append(b, s[start:i]...)
+			b = utf8.AppendRune(b, utf8.RuneError)
+			i += size
+			start = i
+			continue
+		}
+		if r == '\u2028' || r == '\u2029' {
+			b = append(b, s[start:i]...)
+			b = append(b, '\\', 'u', '2', '0', '2', hex[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	b = append(b, s[start:]...)
+	return append(b, '"')
+}
@@ -0,0 +1,125 @@
+package tracker
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+)
+
+// testData returns n checkpoints made a millisecond apart, every tenth one
+// failed and, with phases, every hundred in the next phase
+func testData(n int, phases bool) MetaData {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	data := make(MetaData, n)
+	for i := range data {
+		m := Meta{
+			Name:     fmt.Sprintf("step %d", i%7),
+			Start:    start.Add(time.Duration(i) * time.Millisecond),
+			StartDif: time.Duration(i) * time.Millisecond,
+			File:     "main.go",
+			Line:     10 + i%50,
+			Seq:      uint64(i),
+		}
+		if i > 0 {
+			m.Dur = time.Millisecond
+		}
+		if i%10 == 9 {
+			m.Err = errors.New("failed")
+			m.Attrs = map[string]string{"path": "/etc", "try": "2"}
+			m.Notes = []string{"retried"}
+		}
+		if phases {
+			m.Phase = fmt.Sprintf("phase %d", i/100)
+		}
+		data[i] = m
+	}
+	return data
+}
+
+func TestJSONRenderMatchesMarshalIndent(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		data MetaData
+		want any
+	}{
+		{"plain", testData(25, false), testData(25, false)},
+		{"phases", testData(250, true), testData(250, true).Phases()},
+		{"empty", MetaData{}, MetaData{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			want, err := json.MarshalIndent(tc.want, "", "\t")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got bytes.Buffer
+			JSONRender{Out: &got}.Render(tc.data, nil)
+			if !bytes.Equal(got.Bytes(), want) {
+				t.Errorf("got\n%s\nwant\n%s", got.Bytes(), want)
+			}
+		})
+	}
+}
+
+func TestJSONRenderPhasesNumericUnit(t *testing.T) {
+	var out bytes.Buffer
+	JSONRender{Out: &out, Options: &RenderOptions{NumericUnit: time.Millisecond}}.Render(testData(250, true), nil)
+
+	var phases []struct {
+		Phase       string           `json:"phase"`
+		Dur         float64          `json:"dur_ms"`
+		Checkpoints []map[string]any `json:"checkpoints"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &phases); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.Bytes())
+	}
+	if len(phases) != 3 || phases[1].Phase != "phase 1" || phases[1].Dur != 100 {
+		t.Fatalf("got phases %+v", phases)
+	}
+	if d := phases[1].Checkpoints[0]["dur_ms"]; d != 1.0 {
+		t.Errorf("got dur_ms %v, want 1", d)
+	}
+}
+
+// benchRows is the number of checkpoints of the render benchmarks
+const benchRows = 100000
+
+func BenchmarkJSONRender(b *testing.B) {
+	for _, bc := range []struct {
+		name   string
+		phases bool
+	}{
+		{"plain", false},
+		{"phases", true},
+	} {
+		data := testData(benchRows, bc.phases)
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				JSONRender{Out: io.Discard}.Render(data, nil)
+			}
+		})
+	}
+}
+
+func BenchmarkTableRender(b *testing.B) {
+	data := testData(benchRows, false)
+	for _, bc := range []struct {
+		name string
+		opts *RenderOptions
+	}{
+		{"buffered", nil},
+		{"stream", &RenderOptions{Stream: true}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				TableRender{Out: io.Discard, Options: bc.opts}.Render(data, nil)
+			}
+		})
+	}
+}
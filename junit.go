@@ -0,0 +1,92 @@
+package tracker
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+)
+
+// JUnitRender writes the track as JUnit XML where every checkpoint
+// is a test case, it fails when the checkpoint exceeds its budget and
+// errors when an error was sent into Update(). CI systems then show
+// performance regressions in their test report UI.
+type JUnitRender struct {
+	Out     io.Writer
+	Suite   string
+	Budgets Budgets
+}
+
+type junitSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name      string      `xml:"name,attr"`
+	Tests     int         `xml:"tests,attr"`
+	Failures  int         `xml:"failures,attr"`
+	Errors    int         `xml:"errors,attr"`
+	Time      string      `xml:"time,attr"`
+	Timestamp string      `xml:"timestamp,attr,omitempty"`
+	Cases     []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+}
+
+func (jur JUnitRender) Render(data MetaData, opt *Options) {
+	suite := junitSuite{Name: jur.Suite, Time: seconds(0)}
+	if suite.Name == "" {
+		suite.Name = "tracker"
+	}
+	if len(data) > 0 {
+		suite.Timestamp = data[0].Start.Format("2006-01-02T15:04:05")
+		suite.Time = seconds(data[len(data)-1].StartDif)
+	}
+
+	for _, m := range steps(data) {
+		c := junitCase{
+			Name:      m.Name,
+			ClassName: suite.Name,
+			Time:      seconds(m.Dur),
+		}
+		if jur.Budgets.Exceeded(m) {
+			c.Failure = &junitMessage{
+				Message: fmt.Sprintf("took %s, budget %s", m.Dur, jur.Budgets.Of(m.Name)),
+				Type:    "budget",
+			}
+			suite.Failures++
+		}
+		if m.Err != nil {
+			c.Error = &junitMessage{Message: m.Err.Error(), Type: "error"}
+			suite.Errors++
+		}
+		suite.Cases = append(suite.Cases, c)
+	}
+	suite.Tests = len(suite.Cases)
+
+	payload, err := xml.MarshalIndent(junitSuites{Suites: []junitSuite{suite}}, "", "	")
+	if err != nil {
+		WriteFailed(jur.Out, err, "marshaling data")
+		return
+	}
+	_, err = io.WriteString(jur.Out, xml.Header+string(payload)+"\n")
+	if err != nil {
+		WriteFailed(jur.Out, err, "writing data")
+	}
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.6f", d.Seconds())
+}
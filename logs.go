@@ -0,0 +1,82 @@
+package tracker
+
+import (
+	"bufio"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LogLine is a line of an external log file with its timestamp.
+type LogLine struct {
+	Time time.Time
+	Text string
+}
+
+// ReadLog reads log lines which start with a timestamp in the given
+// layout, e.g. "2006/01/02 15:04:05" for the standard logger. Timestamps
+// without a zone are read in the local time. Lines without a timestamp
+// are skipped.
+func ReadLog(r io.Reader, layout string) ([]LogLine, error) {
+	var (
+		lines []LogLine
+		n     = len(strings.Fields(layout))
+		sc    = bufio.NewScanner(r)
+	)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) < n {
+			continue
+		}
+		ts, err := time.ParseInLocation(layout, strings.Join(fields[:n], " "), time.Local)
+		if err != nil {
+			continue
+		}
+		lines = append(lines, LogLine{Time: ts, Text: strings.Join(fields[n:], " ")})
+	}
+	return lines, sc.Err()
+}
+
+// Interleave returns a copy of data with the log lines inserted between
+// the checkpoints by their time, so renderers show what the application
+// logged in the gaps. Log lines become entries named "log: <text>" with zero
+// duration, lines outside of the tracked period are dropped.
+func Interleave(data MetaData, lines []LogLine) MetaData {
+	if len(data) == 0 {
+		return data
+	}
+
+	lines = append([]LogLine(nil), lines...)
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].Time.Before(lines[j].Time)
+	})
+
+	var (
+		start = data[0].Start
+		end   = data[len(data)-1].Start
+		out   = make(MetaData, 0, len(data)+len(lines))
+		i     int
+	)
+	for _, m := range data {
+		for ; i < len(lines) && lines[i].Time.Before(m.Start); i++ {
+			if lines[i].Time.Before(start) {
+				continue
+			}
+			out = append(out, lines[i].meta(start))
+		}
+		out = append(out, m)
+	}
+	for ; i < len(lines) && !lines[i].Time.After(end); i++ {
+		out = append(out, lines[i].meta(start))
+	}
+	return out
+}
+
+func (l LogLine) meta(start time.Time) Meta {
+	return Meta{
+		Name:     "log: " + l.Text,
+		Start:    l.Time,
+		StartDif: l.Time.Sub(start),
+	}
+}
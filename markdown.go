@@ -0,0 +1,63 @@
+package tracker
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MarkdownRender writes the track as a markdown table, handy for
+// pull requests and review comments. When SourceContext is set and the
+// checkpoints have call sites, the table is followed by that many lines
+// of source around every call site, read at render time.
+type MarkdownRender struct {
+	Out           io.Writer
+	SourceContext int
+}
+
+func (mdr MarkdownRender) Render(data MetaData, opt *Options) {
+	var buf bytes.Buffer
+
+	buf.WriteString("| # | func.name | since.start | duration | errors |\n")
+	buf.WriteString("|---:|---|---:|---:|---|\n")
+	for i, m := range data {
+		var errText string
+		if m.Err != nil {
+			errText = m.Err.Error()
+		}
+		fmt.Fprintf(&buf, "| %d | %s | %s | %s | %s |\n",
+			i, markdownCell(m.Name), m.StartDif, m.Dur, markdownCell(errText))
+	}
+
+	if data.simulated() {
+		buf.WriteString("\n_" + simulatedNote + "_\n")
+	}
+
+	src := newSourceReader(mdr.SourceContext)
+	for i, m := range data {
+		lines := src.context(m)
+		if lines == nil {
+			continue
+		}
+		fmt.Fprintf(&buf, "\n#%d `%s` at %s\n\n```go\n", i, m.Name, m.Link())
+		for _, l := range lines {
+			mark := " "
+			if l.Current {
+				mark = ">"
+			}
+			fmt.Fprintf(&buf, "%s%5d  %s\n", mark, l.Number, l.Text)
+		}
+		buf.WriteString("```\n")
+	}
+
+	if _, err := buf.WriteTo(mdr.Out); err != nil {
+		WriteFailed(mdr.Out, err, "writing data")
+	}
+}
+
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\n", " ")
+
+func markdownCell(s string) string {
+	return markdownEscaper.Replace(s)
+}
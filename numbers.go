@@ -0,0 +1,127 @@
+package tracker
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// NumberFormat holds the separators of numbers in a locale,
+// see RenderOptions.Numbers
+type NumberFormat struct {
+	// Group separates the thousands, Decimal the fraction
+	Group, Decimal string
+}
+
+// the number formats by language, see RegisterNumberFormat
+var numberFormats = map[string]*NumberFormat{
+	"en": {Group: ",", Decimal: "."},
+	"de": {Group: ".", Decimal: ","},
+	"es": {Group: ".", Decimal: ","},
+	"fr": {Group: " ", Decimal: ","},
+	"ru": {Group: " ", Decimal: ","},
+}
+
+var errInvalidNumberFormat = errors.New("number format needs a decimal separator")
+
+// RegisterNumberFormat adds the number format of the language or
+// replaces it, it is safe to call while rendering
+func RegisterNumberFormat(lang string, f *NumberFormat) error {
+	if f == nil || f.Decimal == "" {
+		return errInvalidNumberFormat
+	}
+
+	langMu.Lock()
+	defer langMu.Unlock()
+	numberFormats[lang] = f
+	return nil
+}
+
+// LookupNumberFormat returns the number format of the language tag the
+// same way as LookupCatalog, an unknown language gets the English one
+func LookupNumberFormat(lang string) *NumberFormat {
+	return lookupLang(numberFormats, lang)
+}
+
+// lookupLang returns the value of the language tag, e.g. "de-AT"
+// falls back to "de", and an unknown language to English
+func lookupLang[T any](byLang map[string]T, lang string) T {
+	langMu.RLock()
+	defer langMu.RUnlock()
+
+	lang = strings.ReplaceAll(lang, "_", "-")
+	if v, ok := byLang[lang]; ok {
+		return v
+	}
+	if i := strings.IndexByte(lang, '-'); i > 0 {
+		if v, ok := byLang[lang[:i]]; ok {
+			return v
+		}
+	}
+	return byLang["en"]
+}
+
+// Int formats n with the thousands separated, e.g. "12.345" in German
+func (nf *NumberFormat) Int(n int64) string {
+	s := strconv.FormatInt(n, 10)
+	sign := ""
+	if n < 0 {
+		sign, s = "-", s[1:]
+	}
+	return sign + group(s, nf.Group)
+}
+
+// Float formats x with prec digits of the fraction, e.g. "1.234,50" in German
+func (nf *NumberFormat) Float(x float64, prec int) string {
+	s := strconv.FormatFloat(x, 'f', prec, 64)
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	whole, frac, ok := strings.Cut(s, ".")
+	s = sign + group(whole, nf.Group)
+	if ok {
+		s += nf.Decimal + frac
+	}
+	return s
+}
+
+// localize replaces the decimal point of a formatted number like
+// "1234.500ms" and separates the thousands of its whole part
+func (nf *NumberFormat) localize(s string) string {
+	whole, rest, ok := strings.Cut(s, ".")
+	if !ok {
+		return s
+	}
+	if strings.Trim(whole, "0123456789") == "" && whole != "" {
+		whole = group(whole, nf.Group)
+	}
+	return whole + nf.Decimal + rest
+}
+
+// group separates the thousands of the digits
+func group(digits, sep string) string {
+	start := (len(digits)-1)%3 + 1
+
+	var b strings.Builder
+	b.WriteString(digits[:start])
+	for i := start; i < len(digits); i += 3 {
+		b.WriteString(sep)
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
+
+// groupDigits formats n >= 0 with the thousands separated by commas
+func groupDigits(n int) string {
+	return group(strconv.Itoa(n), ",")
+}
+
+// count formats a count column, the thousands are separated
+// only if Options.Numbers is set
+func (tbr TableRender) count(n int64) string {
+	if tbr.Options == nil || tbr.Options.Numbers == nil {
+		return strconv.FormatInt(n, 10)
+	}
+	return tbr.Options.Numbers.Int(n)
+}
@@ -0,0 +1,69 @@
+package tracker
+
+import "time"
+
+// MaxRows makes TableRender show only the first and the last n rows of
+// huge tracks and the pinned ones (see UpdatePinned), every run of rows
+// in between is summed up in a single row like "… 4,312 more checkpoints"
+// with their total duration
+func (o *Options) MaxRows(n int) *Options {
+	o.maxRows = n
+	return o
+}
+
+// overflow holds the runs of rows hidden by MaxRows
+type overflow struct {
+	hidden []bool
+	// the summary rows by the index of the first hidden row of the run
+	rows map[int][]string
+}
+
+func newOverflow(data MetaData, opt *Options, f columnFormats) overflow {
+	n := opt.maxRows
+	if n <= 0 || len(data) <= 2*n {
+		return overflow{}
+	}
+
+	ov := overflow{hidden: make([]bool, len(data)), rows: make(map[int][]string)}
+	for i := n; i < len(data)-n; i++ {
+		ov.hidden[i] = !data[i].Pinned
+	}
+	for i := 0; i < len(data); {
+		if !ov.hidden[i] {
+			i++
+			continue
+		}
+		from := i
+		var total time.Duration
+		for ; i < len(data) && ov.hidden[i]; i++ {
+			total += data[i].Dur
+		}
+		ov.rows[from] = overflowRow(opt, i-from, total, f)
+	}
+	return ov
+}
+
+// overflowRow sums up a run of hidden rows in a summary row
+func overflowRow(opt *Options, count int, total time.Duration, f columnFormats) []string {
+	n := groupDigits(count)
+	if f.numbers != nil {
+		n = f.numbers.Int(int64(count))
+	}
+	d := f.duration(total)
+	return summaryRow(opt, "… "+n+" more checkpoints", "total "+d, " (total "+d+")")
+}
+
+// hides reports whether the row i is hidden
+func (ov overflow) hides(i int) bool {
+	return i < len(ov.hidden) && ov.hidden[i]
+}
+
+// shown returns the number of the rows shown, the summary rows included
+func (ov overflow) shown(n int) int {
+	for _, h := range ov.hidden {
+		if h {
+			n--
+		}
+	}
+	return n + len(ov.rows)
+}
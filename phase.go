@@ -0,0 +1,164 @@
+package tracker
+
+import (
+	"bufio"
+	"bytes"
+	"time"
+)
+
+// Phase starts the named phase of the track: the next checkpoints are
+// grouped under it until the next phase, renderers show the subtotals
+// of the phases
+func (t *Track) Phase(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.phase = name
+}
+
+// Phase is a run of consecutive checkpoints of the same phase
+type Phase struct {
+	Name string `json:"phase"`
+	// Dur is the subtotal of the durations of the checkpoints
+	Dur         time.Duration `json:"dur"`
+	Checkpoints MetaData      `json:"checkpoints"`
+}
+
+// Phases splits the data into the runs of checkpoints of the same phase,
+// the checkpoints made before the first phase make an unnamed one
+func (m MetaData) Phases() []Phase {
+	var phases []Phase
+	for i, e := range m {
+		if i == 0 || e.Phase != m[i-1].Phase {
+			phases = append(phases, Phase{Name: e.Phase})
+		}
+		p := &phases[len(phases)-1]
+		p.Dur += e.Dur
+		p.Checkpoints = append(p.Checkpoints, e)
+	}
+	return phases
+}
+
+func (m MetaData) hasPhases() bool {
+	for _, e := range m {
+		if e.Phase != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// writePhases streams the data nested under the phases as
+// json.MarshalIndent(data.Phases(), "", "\t") encodes them, without
+// building the phases in memory. The durations are numbers of the unit
+// if it is set, see RenderOptions.NumericUnit.
+func writePhases(w *bufio.Writer, data MetaData, unit time.Duration) error {
+	durKey := "dur"
+	if unit > 0 {
+		durKey += "_" + unitName(unit)
+	}
+
+	buf := make([]byte, 0, 512)
+	w.WriteString("[")
+	for i := 0; i < len(data); {
+		j := i
+		var dur time.Duration
+		for ; j < len(data) && data[j].Phase == data[i].Phase; j++ {
+			dur += data[j].Dur
+		}
+
+		if i > 0 {
+			w.WriteString(",")
+		}
+		buf = append(buf[:0], "\n\t{\n\t\t\"phase\": "...)
+		buf = appendString(buf, data[i].Phase)
+		buf = append(buf, ",\n\t\t\""+durKey+"\": "...)
+		buf = appendNumeric(buf, dur, unit)
+		buf = append(buf, ",\n\t\t\"checkpoints\": ["...)
+		w.Write(buf)
+
+		for k, m := range data[i:j] {
+			if k > 0 {
+				w.WriteString(",")
+			}
+			w.WriteString("\n\t\t\t")
+
+			var err error
+			if buf, err = appendMeta(buf[:0], m, unit); err != nil {
+				return err
+			}
+			// appendMeta indents for the top level array, the
+			// checkpoints are nested two levels deeper
+			for b := buf; ; {
+				n := bytes.IndexByte(b, '\n')
+				if n < 0 {
+					w.Write(b)
+					break
+				}
+				w.Write(b[:n+1])
+				w.WriteString("\t\t")
+				b = b[n+1:]
+			}
+		}
+		w.WriteString("\n\t\t]\n\t}")
+		i = j
+	}
+	if len(data) > 0 {
+		w.WriteString("\n")
+	}
+	w.WriteString("]")
+	return nil
+}
+
+// phaseRows returns the separator rows of the named phases with their
+// subtotals, by the index of the first checkpoint of the phase
+func phaseRows(data MetaData, opt *Options, f columnFormats) map[int][]string {
+	if !data.hasPhases() {
+		return nil
+	}
+
+	rows := make(map[int][]string)
+	var i int
+	for _, p := range data.Phases() {
+		if p.Name != "" {
+			rows[i] = phaseRow(opt, p, f)
+		}
+		i += len(p.Checkpoints)
+	}
+	return rows
+}
+
+// phaseRow puts the phase name into the name column and its subtotal
+// into the duration column
+func phaseRow(opt *Options, p Phase, f columnFormats) []string {
+	d := f.duration(p.Dur)
+	return summaryRow(opt, "["+p.Name+"]", d, " "+d)
+}
+
+// summaryRow returns a row of the columns of opt with the text in the
+// name column and dur in the duration column, the first column is used
+// for the name if it is not shown and inline is appended to the text if
+// the duration is not shown
+func summaryRow(opt *Options, text, dur, inline string) []string {
+	row := createHeaders(nil, opt)
+	name, col := 0, -1
+	for i, h := range row {
+		switch h {
+		case "func.name":
+			name = i
+		case "duration":
+			col = i
+		}
+		row[i] = ""
+	}
+	if len(row) == 0 {
+		return row
+	}
+
+	row[name] = text
+	if col >= 0 {
+		row[col] = dur
+	} else {
+		row[name] += inline
+	}
+	return row
+}
@@ -0,0 +1,20 @@
+package tracker
+
+// UpdatePinned works as Update() and pins the checkpoint: pinned
+// checkpoints are key milestones which are never dropped, neither by
+// the Sampler nor from compact views, the rows hidden by MaxRows and
+// the delta renders of RenderEvery.
+//
+//	t.UpdatePinned(nil)
+func (t *Track) UpdatePinned(err error) error {
+	if ok, err := t.begin(); !ok {
+		return err
+	}
+
+	meta := trace(t.callerSkip)
+	meta.Err = err
+	meta.Pinned = true
+	t.add(meta, "")
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package tracker
+
+import (
+	"testing"
+	"time"
+)
+
+type dropAll struct{}
+
+func (dropAll) Sample(Meta) bool { return false }
+
+func TestUpdatePinnedSurvivesSampling(t *testing.T) {
+	tr, clock := simTrack(t)
+	tr.SetSampler(dropAll{})
+
+	clock.Advance(time.Millisecond)
+	tr.Update(nil)
+	clock.Advance(2 * time.Millisecond)
+	tr.UpdatePinned(nil)
+	clock.Advance(time.Millisecond)
+	tr.Update(nil)
+
+	data := tr.Snapshot()
+	if len(data) != 2 {
+		t.Fatalf("got %d checkpoints, want the creation and the pinned one", len(data))
+	}
+	m := data[1]
+	if !m.Pinned {
+		t.Error("the checkpoint is not pinned")
+	}
+	// the dropped checkpoint leaves a gap, the pinned one keeps its duration
+	if m.Dur != 2*time.Millisecond || m.StartDif != 3*time.Millisecond {
+		t.Errorf("got dur %v since start %v, want 2ms since 3ms", m.Dur, m.StartDif)
+	}
+}
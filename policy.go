@@ -0,0 +1,156 @@
+package tracker
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	"time"
+)
+
+// defaultRuleWindow is the number of latest durations a rule computes
+// the quantile of
+const defaultRuleWindow = 100
+
+// Rule is a declarative alerting threshold evaluated by an aggregator
+// after each added track: the rule fires when the Quantile of the
+// latest durations of the Checkpoint is over Threshold for For
+// consecutive tracks, e.g.
+//
+//	Rule{Checkpoint: "db.Query", Quantile: 0.95, Threshold: 200 * time.Millisecond, For: 5}
+//
+// Rules ignore the aggregator dimensions.
+type Rule struct {
+	Name       string
+	Checkpoint string
+	// Quantile in (0, 1], 0 means the max
+	Quantile  float64
+	Threshold time.Duration
+	// For is the number of consecutive breaching tracks, at least 1
+	For int
+	// Window is the number of latest durations the quantile is computed
+	// over, 100 if not positive
+	Window int
+}
+
+func (r Rule) String() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	q := r.Quantile
+	if q <= 0 || q > 1 {
+		q = 1
+	}
+	return fmt.Sprintf("%s p%g over %s for %d runs", r.Checkpoint, q*100, r.Threshold, max(r.For, 1))
+}
+
+// Alert is sent to the notifiers when a rule fires or resolves
+type Alert struct {
+	Rule Rule
+	// Value is the quantile of the latest durations
+	Value    time.Duration
+	Time     time.Time
+	Resolved bool
+}
+
+// Notifier exports the alerts of an aggregator: to a chat, a pager...
+type Notifier interface {
+	Notify(Alert) error
+}
+
+// NotifierFunc is an adapter to use ordinary functions as notifiers
+type NotifierFunc func(Alert) error
+
+func (f NotifierFunc) Notify(a Alert) error {
+	return f(a)
+}
+
+// ruleState is a rule with its evaluation state
+type ruleState struct {
+	Rule
+	durs     []time.Duration
+	breaches int
+	firing   bool
+}
+
+// AddRule registers the rule, it is evaluated on the tracks added after
+func (a *Aggregator) AddRule(r Rule) {
+	if r.Window <= 0 {
+		r.Window = defaultRuleWindow
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.rules = append(a.rules, &ruleState{Rule: r})
+}
+
+// AddNotifier registers the notifier of the alerts of the rules
+func (a *Aggregator) AddNotifier(n Notifier) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.notifiers = append(a.notifiers, n)
+}
+
+// evaluate updates the rules with the steps of the added data and
+// returns the alerts to send, it must be called with a.mu held
+func (a *Aggregator) evaluate(data MetaData, now time.Time) []Alert {
+	var alerts []Alert
+	for _, r := range a.rules {
+		seen := false
+		for _, m := range steps(data) {
+			if m.Name != r.Checkpoint {
+				continue
+			}
+			seen = true
+			r.durs = append(r.durs, m.Dur)
+		}
+		if !seen {
+			continue
+		}
+		if len(r.durs) > r.Window {
+			r.durs = append(r.durs[:0], r.durs[len(r.durs)-r.Window:]...)
+		}
+
+		v := quantile(r.durs, r.Quantile)
+		if v <= r.Threshold {
+			r.breaches = 0
+			if r.firing {
+				r.firing = false
+				alerts = append(alerts, Alert{Rule: r.Rule, Value: v, Time: now, Resolved: true})
+			}
+			continue
+		}
+
+		r.breaches++
+		if !r.firing && r.breaches >= max(r.For, 1) {
+			r.firing = true
+			alerts = append(alerts, Alert{Rule: r.Rule, Value: v, Time: now})
+		}
+	}
+	return alerts
+}
+
+// notify sends the alerts to the notifiers, it must be called
+// without a.mu held as notifiers may be slow
+func notify(notifiers []Notifier, alerts []Alert) {
+	for _, alert := range alerts {
+		for _, n := range notifiers {
+			if err := n.Notify(alert); err != nil {
+				log.Printf("err:%s; error notifying alert %q", err.Error(), alert.Rule.String())
+			}
+		}
+	}
+}
+
+// quantile returns the nearest-rank q quantile of durs, the max if q is
+// out of (0, 1]
+func quantile(durs []time.Duration, q float64) time.Duration {
+	if len(durs) == 0 {
+		return 0
+	}
+	if q <= 0 || q > 1 {
+		return slices.Max(durs)
+	}
+	sorted := slices.Clone(durs)
+	slices.Sort(sorted)
+	return sorted[int(math.Ceil(q*float64(len(sorted))))-1]
+}
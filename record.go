@@ -0,0 +1,24 @@
+package tracker
+
+import "time"
+
+// Record records a checkpoint measured outside of the track, e.g. the
+// server side time of a database query or the latency reported by an
+// upstream header, so timings of other systems are merged into the same
+// report. start is when the operation began, the checkpoint is placed at
+// its end like the ones of Update, its duration is dur instead of the
+// time since the previous checkpoint.
+func (t *Track) Record(name string, start time.Time, dur time.Duration, err error) error {
+	if ok, err := t.begin(); !ok {
+		return err
+	}
+
+	meta := trace(t.callerSkip)
+	meta.Name = name
+	meta.Start = start.Add(dur)
+	meta.Dur = dur
+	meta.Err = err
+	t.insert(meta)
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package tracker
+
+import (
+	"bytes"
+	"encoding/json"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// tableHeader is the table of no rows
+const tableHeader = `+-----------+-------------+----------+--------+
+| FUNC NAME | SINCE START | DURATION | ERRORS |
++-----------+-------------+----------+--------+
++-----------+-------------+----------+--------+
+`
+
+// emptyData are the data of a zero-value track never updated
+// and of a cleared snapshot
+func emptyData() map[string]MetaData {
+	return map[string]MetaData{
+		"nil":   nil,
+		"empty": {},
+	}
+}
+
+func TestRenderersEmptyData(t *testing.T) {
+	var out bytes.Buffer
+	tests := []struct {
+		name string
+		r    Renderer
+		want string
+	}{
+		{"table", TableRender{Out: &out}, tableHeader},
+		{"stream", TableRender{Out: &out, Options: &RenderOptions{Stream: true}}, tableHeader},
+		{"waterfall", TableRender{Out: &out, Options: &RenderOptions{Waterfall: true}}, tableHeader},
+		{"csv", CSVRender{Out: &out}, "func.name,since.start,duration,errors\n"},
+		{"markdown", MarkdownRender{Out: &out}, "| # | func.name | since.start | duration | errors |\n|---:|---|---:|---:|---|\n"},
+		{"tap", TAPRender{Out: &out}, "TAP version 13\n1..0\n"},
+		{"junit", JUnitRender{Out: &out}, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<testsuites>\n\t<testsuite name=\"tracker\" tests=\"0\" failures=\"0\" errors=\"0\" time=\"0.000000\"></testsuite>\n</testsuites>\n"},
+		{"har", HARRender{Out: &out}, "{\n\t\"log\": {\n\t\t\"version\": \"1.2\",\n\t\t\"creator\": {\n\t\t\t\"name\": \"tracker\",\n\t\t\t\"version\": \"1\"\n\t\t},\n\t\t\"pages\": null,\n\t\t\"entries\": []\n\t}\n}"},
+		{"github", GitHubRender{Out: &out}, ""},
+		{"dump", DumpRender{Out: &out}, "{\n\t\"version\": 1,\n\t\"start\": \"0001-01-01T00:00:00Z\",\n\t\"spans\": [],\n\t\"files\": []\n}\n"},
+	}
+
+	for name, data := range emptyData() {
+		for _, tt := range tests {
+			out.Reset()
+			tt.r.Render(data, nil)
+			if got := out.String(); got != tt.want {
+				t.Errorf("%s/%s: got\n%s\nwant\n%s", name, tt.name, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestJSONRenderEmptyData(t *testing.T) {
+	want := map[string]string{"nil": "null", "empty": "[]"}
+	for name, data := range emptyData() {
+		var out bytes.Buffer
+		JSONRender{Out: &out}.Render(data, nil)
+		if got := out.String(); got != want[name] {
+			t.Errorf("%s: got %q, want %q", name, got, want[name])
+		}
+	}
+}
+
+func TestHTMLRenderEmptyData(t *testing.T) {
+	for name, data := range emptyData() {
+		var out bytes.Buffer
+		HTMLRender{Out: &out}.Render(data, nil)
+		for _, want := range []string{"\tvar total =  0  || 1;\n", "\tvar rows = [];\n"} {
+			if !strings.Contains(out.String(), want) {
+				t.Errorf("%s: missing %q in\n%s", name, want, out.String())
+			}
+		}
+	}
+}
+
+func TestBenchstatRenderEmptyData(t *testing.T) {
+	want := "goos: " + runtime.GOOS + "\ngoarch: " + runtime.GOARCH + "\n"
+	for name, data := range emptyData() {
+		var out bytes.Buffer
+		BenchstatRender{Out: &out}.Render(data, nil)
+		if got := out.String(); got != want {
+			t.Errorf("%s: got %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestRollupsEmptyData(t *testing.T) {
+	for name, data := range emptyData() {
+		if got, want := data.Summary(), "0 checkpoints"; got != want {
+			t.Errorf("%s: Summary got %q, want %q", name, got, want)
+		}
+
+		a := NewAggregator()
+		a.AddData(nil, data)
+		if stats := a.Stats(); len(stats) != 0 {
+			t.Errorf("%s: got stats %v of no steps", name, stats)
+		}
+	}
+}
+
+func TestGroupByEmptyData(t *testing.T) {
+	for name, data := range emptyData() {
+		groups := data.GroupBy(Meta.Package)
+		if groups == nil || len(groups) != 0 {
+			t.Errorf("%s: got %#v, want no groups", name, groups)
+		}
+		if b, _ := json.Marshal(groups); string(b) != "[]" {
+			t.Errorf("%s: encodes as %s, want []", name, b)
+		}
+	}
+}
+
+func TestRenderComparisonEmptyData(t *testing.T) {
+	after := MetaData{{Name: "main"}, {Name: "load", Dur: 2 * time.Millisecond}}
+	want := `+-----------+--------+-------+-------+
+| FUNC NAME | BEFORE | AFTER | DELTA |
++-----------+--------+-------+-------+
+| load      | absent | 2ms   |       |
++-----------+--------+-------+-------+
+`
+	for name, data := range emptyData() {
+		var out bytes.Buffer
+		TableRender{Out: &out}.RenderComparison([]string{"before", "after"}, data, after)
+		if got := out.String(); got != want {
+			t.Errorf("%s: got\n%s\nwant\n%s", name, got, want)
+		}
+	}
+}
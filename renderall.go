@@ -0,0 +1,78 @@
+package tracker
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// RenderAll renders a single snapshot of t with every renderer into its
+// own output, ordered by the names, so all the artifacts describe exactly
+// the same data even while checkpoints are still recorded
+func (t *Track) RenderAll(renderers map[string]Renderer) {
+	data := t.snapshot()
+	for _, name := range sortedNames(renderers) {
+		renderers[name].Render(data, t.options)
+	}
+}
+
+// RenderFiles works as RenderAll, but every renderer writes into the
+// file of its name in dir instead of its Out, e.g.
+//
+//	t.RenderFiles("report", map[string]tracker.Renderer{
+//		"table.txt":   tracker.TableRender{},
+//		"report.json": tracker.JSONRender{},
+//		"report.html": tracker.HTMLRender{Title: "nightly"},
+//	})
+//
+// The renderers must have an Out io.Writer field like the ones of this
+// package. The files which can't be written are reported together, the
+// other ones are written anyway.
+func (t *Track) RenderFiles(dir string, renderers map[string]Renderer) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	data := t.snapshot()
+	var errs []error
+	for _, name := range sortedNames(renderers) {
+		if err := renderFile(filepath.Join(dir, name), renderers[name], data, t.options); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func renderFile(path string, r Renderer, data MetaData, opt *Options) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	// the failures of writing are kept by the policy writer, not logged
+	out := WritePolicy{OnFailure: ReturnFailure}.Wrap(f)
+	r, err = withOut(r, out)
+	if err != nil {
+		f.Close()
+		os.Remove(path)
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	r.Render(data, opt)
+
+	if err = out.Err(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func sortedNames(renderers map[string]Renderer) []string {
+	names := make([]string, 0, len(renderers))
+	for name := range renderers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,30 @@
+package tracker
+
+import "regexp"
+
+// Rewrite replaces the matches of Pattern in the function names,
+// Replacement may refer to the groups as in regexp.ReplaceAllString
+type Rewrite struct {
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+// GeneratedSuffix is a rewrite rule dropping the suffixes the compiler
+// adds to the names of closures, e.g. "main.run.func1.2" becomes "main.run"
+var GeneratedSuffix = Rewrite{Pattern: regexp.MustCompile(`(\.func\d+)+(\.\d+)*$`)}
+
+// SetRewrites makes t rewrite the function names by the rules in order
+// before the checkpoints are stored, so names with generated suffixes
+// or version hashes aggregate stably
+func (t *Track) SetRewrites(rules ...Rewrite) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rewrites = append([]Rewrite(nil), rules...)
+}
+
+func rewrite(rules []Rewrite, name string) string {
+	for _, r := range rules {
+		name = r.Pattern.ReplaceAllString(name, r.Replacement)
+	}
+	return name
+}
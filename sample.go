@@ -0,0 +1,109 @@
+package tracker
+
+import (
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// Sampler decides whether a measured checkpoint is stored, a dropped
+// checkpoint leaves a gap in the timeline: the next one keeps its own
+// duration and its time since the start
+type Sampler interface {
+	Sample(m Meta) bool
+}
+
+// SetSampler makes t store only the checkpoints the sampler keeps
+func (t *Track) SetSampler(s Sampler) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.sampler = s
+}
+
+// AdaptiveSampler keeps every checkpoint while they come slower than
+// Rate per second, above it the sampling probability is lowered to keep
+// about Rate per second. Checkpoints with errors and outliers are always
+// kept: outliers are longer than Outlier if it is set, or exceed the
+// moving mean by more than three standard deviations of the (log scaled)
+// durations seen so far while being at least four times the typical
+// duration. It is safe for concurrent use and can be shared by many
+// tracks (see Factory.SetSampler).
+type AdaptiveSampler struct {
+	Rate    float64
+	Outlier time.Duration
+
+	mu          sync.Mutex
+	window      time.Time
+	seen        float64
+	probability float64
+	mean, dev   float64
+	n           int
+}
+
+func NewAdaptiveSampler(rate float64) *AdaptiveSampler {
+	return &AdaptiveSampler{Rate: rate, probability: 1}
+}
+
+const (
+	// the weight of a new duration in the moving mean and deviation
+	sampleAlpha = 0.05
+	// how many times an outlier is longer than the typical duration at least
+	outlierRatio = 4
+)
+
+func (s *AdaptiveSampler) Sample(m Meta) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.window.IsZero() {
+		s.window, s.probability = m.Start, 1
+	}
+	if elapsed := m.Start.Sub(s.window); elapsed >= time.Second {
+		// the rate of the finished window sets the probability of the next one
+		rate := s.seen / elapsed.Seconds()
+		s.probability = 1
+		if rate > s.Rate && s.Rate > 0 {
+			s.probability = s.Rate / rate
+		}
+		s.window, s.seen = m.Start, 0
+	}
+	s.seen++
+
+	outlier := s.outlier(m.Dur)
+	if m.Err != nil || outlier {
+		return true
+	}
+
+	p := s.probability
+	if s.seen > s.Rate && s.Rate > 0 {
+		// the rate is already over within the current window, do not wait
+		// for the next one to lower the probability
+		elapsed := math.Max(m.Start.Sub(s.window).Seconds(), 1e-3)
+		p = math.Min(p, s.Rate*elapsed/s.seen)
+	}
+	return p >= 1 || rand.Float64() < p
+}
+
+// outlier reports whether d is an outlier and adds it to the moving stats
+func (s *AdaptiveSampler) outlier(d time.Duration) bool {
+	if s.Outlier > 0 && d > s.Outlier {
+		return true
+	}
+
+	// latencies are long tailed, their logarithm is closer to normal
+	x := math.Log1p(float64(d))
+	s.n++
+	if s.n == 1 {
+		s.mean = x
+		return false
+	}
+
+	// the deviation alone flags too much of a long tail, an outlier
+	// must also be several times longer than the typical duration
+	outlier := s.n > 20 && x-s.mean > 3*math.Sqrt(s.dev) && x-s.mean > math.Log(outlierRatio)
+	diff := x - s.mean
+	s.mean += sampleAlpha * diff
+	s.dev = (1 - sampleAlpha) * (s.dev + sampleAlpha*diff*diff)
+	return outlier
+}
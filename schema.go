@@ -0,0 +1,120 @@
+package tracker
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// SchemaID is the $id of the schema returned by Schema
+const SchemaID = "https://github.com/cat-in-vacuum/tracker/schema.json"
+
+// Schema returns the JSON Schema (draft 2020-12) of the serialized
+// output, so parsers in other languages can be generated from it:
+// the root describes the JSONRender output, a list of checkpoints or of
+// phases, and $defs hold Meta, Phase, Dashboard, Export, Dump and the rest.
+// Durations are integer nanoseconds, times are RFC 3339 strings; the
+// output of RenderOptions.NumericUnit is not covered.
+func Schema() []byte {
+	defs := make(map[string]any)
+	for _, v := range []any{Meta{}, Phase{}, Dashboard{}, Export{}, Dump{}} {
+		schemaOf(reflect.TypeOf(v), defs)
+	}
+
+	root := map[string]any{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$id":     SchemaID,
+		"title":   "tracker output",
+		"oneOf": []any{
+			map[string]any{"type": "array", "items": map[string]any{"$ref": "#/$defs/Meta"}},
+			map[string]any{"type": "array", "items": map[string]any{"$ref": "#/$defs/Phase"}},
+			map[string]any{"type": "null"},
+		},
+		"$defs": defs,
+	}
+	b, err := json.MarshalIndent(root, "", "\t")
+	if err != nil {
+		// the schema is made of maps, slices and strings only
+		panic(err)
+	}
+	return b
+}
+
+// SchemaHandler returns a http.Handler serving Schema
+func SchemaHandler() http.Handler {
+	schema := Schema()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/schema+json")
+		if _, err := w.Write(schema); err != nil {
+			WriteFailed(w, err, "writing schema")
+		}
+	})
+}
+
+var (
+	durationType = reflect.TypeFor[time.Duration]()
+	timeType     = reflect.TypeFor[time.Time]()
+)
+
+// schemaOf returns the schema of the type, the structs are put into defs
+// and referred to
+func schemaOf(t reflect.Type, defs map[string]any) map[string]any {
+	switch t {
+	case durationType:
+		return map[string]any{"type": "integer", "description": "nanoseconds"}
+	case timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case errorType:
+		return map[string]any{"description": "the error encoded by encoding/json, null if there is no error"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice:
+		return map[string]any{"type": []string{"array", "null"}, "items": schemaOf(t.Elem(), defs)}
+	case reflect.Pointer:
+		return schemaOf(t.Elem(), defs)
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaOf(t.Elem(), defs)}
+	case reflect.Struct:
+		name := t.Name()
+		if _, ok := defs[name]; !ok {
+			defs[name] = nil // breaks the recursion of self-referring types
+			defs[name] = structSchema(t, defs)
+		}
+		return map[string]any{"$ref": "#/$defs/" + name}
+	}
+	return map[string]any{}
+}
+
+func structSchema(t reflect.Type, defs map[string]any) map[string]any {
+	props := make(map[string]any)
+	required := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if !f.IsExported() || tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = f.Name
+		}
+		props[name] = schemaOf(f.Type, defs)
+		if !strings.Contains(opts, "omitempty") {
+			required = append(required, name)
+		}
+	}
+	return map[string]any{"type": "object", "properties": props, "required": required}
+}
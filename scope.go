@@ -0,0 +1,48 @@
+package tracker
+
+// Scope is a view of a track which records the checkpoints on the
+// track with their names prefixed, e.g. "db/" or "cache/": cheap
+// namespacing of the parts of a program without nested tracks.
+type Scope struct {
+	t      *Track
+	prefix string
+}
+
+// Scope returns a view of t prefixing the names of its checkpoints
+func (t *Track) Scope(prefix string) Scope {
+	return Scope{t: t, prefix: prefix}
+}
+
+// Scope returns a nested view, its prefix is appended to the prefix of s
+func (s Scope) Scope(prefix string) Scope {
+	return Scope{t: s.t, prefix: s.prefix + prefix}
+}
+
+// Update works as Track.Update() with the name prefixed
+func (s Scope) Update(err error) error {
+	t := s.t
+	if ok, err := t.begin(); !ok {
+		return err
+	}
+
+	meta := trace(t.callerSkip)
+	meta.Err = err
+	t.add(meta, s.prefix)
+
+	return nil
+}
+
+// UpdateWithID works as Track.UpdateWithID() with the name prefixed
+func (s Scope) UpdateWithID(id string, err error) error {
+	t := s.t
+	if ok, err := t.begin(); !ok {
+		return err
+	}
+
+	meta := trace(t.callerSkip)
+	meta.ID = id
+	meta.Err = err
+	t.add(meta, s.prefix)
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package tracker
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SelfTest is the result of RunSelfTest, it tells which checkpoint
+// durations are trustworthy on the host, so noise of a coarse clock
+// is not chased as a slowdown
+type SelfTest struct {
+	// Resolution is the smallest step of the clock observed
+	Resolution time.Duration
+	// NowCost is the mean cost of reading the clock
+	NowCost time.Duration
+	// Overhead is the mean cost of an Update
+	Overhead time.Duration
+	// MinTrusted is the shortest duration measured within 10%,
+	// ten times the larger of Resolution and Overhead
+	MinTrusted time.Duration
+}
+
+const (
+	// selfTestBudget is the time spent on measuring every figure,
+	// so a coarse clock does not make the self-test take seconds
+	selfTestBudget = 20 * time.Millisecond
+	// the caps of the clock reads and the Updates measured
+	selfTestReads   = 100000
+	selfTestUpdates = 1000
+)
+
+// RunSelfTest measures the timer resolution of the host and the
+// overhead of the tracker per call, it takes under 100 milliseconds
+func RunSelfTest() SelfTest {
+	var st SelfTest
+
+	st.Resolution = time.Hour
+	for begin := time.Now(); time.Since(begin) < selfTestBudget; {
+		start := time.Now()
+		next := time.Now()
+		for next.Equal(start) {
+			next = time.Now()
+		}
+		st.Resolution = min(st.Resolution, next.Sub(start))
+	}
+
+	st.NowCost = measure(selfTestReads, func() { _ = time.Now() })
+
+	// the track keeps at most selfTestUpdates checkpoints
+	t := New(0)
+	st.Overhead = measure(selfTestUpdates, func() { t.Update(nil) })
+
+	st.MinTrusted = 10 * max(st.Resolution, st.Overhead)
+	return st
+}
+
+// measure calls f up to n times within selfTestBudget
+// and returns the mean duration of a call
+func measure(n int, f func()) time.Duration {
+	start := time.Now()
+	var i int
+	for i < n {
+		f()
+		i++
+		// the clock is read rarely not to add its cost to f
+		if i%64 == 0 && time.Since(start) >= selfTestBudget {
+			break
+		}
+	}
+	return time.Since(start) / time.Duration(i)
+}
+
+// Trusted reports whether a checkpoint of the duration is measured within 10%
+func (st SelfTest) Trusted(d time.Duration) bool {
+	return d >= st.MinTrusted
+}
+
+func (st SelfTest) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "timer resolution: %s\n", st.Resolution)
+	fmt.Fprintf(&b, "clock read cost:  %s\n", st.NowCost)
+	fmt.Fprintf(&b, "update overhead:  %s\n", st.Overhead)
+	fmt.Fprintf(&b, "min trusted:      %s\n", st.MinTrusted)
+	if st.Trusted(100 * time.Microsecond) {
+		b.WriteString("checkpoints under 100µs are trustworthy on this host\n")
+	} else {
+		b.WriteString("checkpoints under 100µs are NOT trustworthy on this host\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,101 @@
+package tracker
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+)
+
+// RenderSize is the size of a rendered output, see EstimateSize
+type RenderSize struct {
+	// Rows is the number of the checkpoint rows, the overflow rows of
+	// MaxRows and the phase rows included for the renderers showing them
+	Rows  int
+	Lines int
+	Bytes int
+}
+
+var errNoOut = errors.New("the renderer has no Out writer")
+
+// EstimateSize renders data with r into a counter instead of its output
+// and returns the size of the output, so callers writing to constrained
+// sinks (chat message limits, UDP datagrams) can pick a more compact
+// renderer or MaxRows first. r must be a struct, or a pointer to one,
+// with an Out io.Writer field like the renderers of this package.
+func EstimateSize(r Renderer, data MetaData, opt *Options) (RenderSize, error) {
+	var c sizeCounter
+	dry, err := withOut(r, &c)
+	if err != nil {
+		return RenderSize{}, err
+	}
+	dry.Render(data, opt)
+
+	return RenderSize{Rows: estimateRows(r, data, opt), Lines: c.lines, Bytes: c.bytes}, nil
+}
+
+// estimateRows returns the number of the rows rendered by r: only
+// TableRender and HTMLRender sum up rows by MaxRows, and only TableRender
+// shows the phase rows, the other renderers write every checkpoint
+func estimateRows(r Renderer, data MetaData, opt *Options) int {
+	var table bool
+	switch r.(type) {
+	case TableRender, *TableRender:
+		table = true
+	case HTMLRender, *HTMLRender:
+		// it sums up rows by MaxRows, but shows no phase rows
+	default:
+		return len(data)
+	}
+	if opt == nil {
+		opt = defaultOptions()
+	}
+
+	f := (&RenderOptions{}).formats(data)
+	overflow := newOverflow(data, opt, f)
+	rows := overflow.shown(len(data))
+	if table {
+		for i := range phaseRows(data, opt, f) {
+			if !overflow.hides(i) {
+				rows++
+			}
+		}
+	}
+	return rows
+}
+
+// withOut returns a copy of r writing into w, r must be a struct, or
+// a pointer to one, with an Out io.Writer field
+func withOut(r Renderer, w io.Writer) (Renderer, error) {
+	v := reflect.ValueOf(r)
+	ptr := v.Kind() == reflect.Pointer
+	if ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, errNoOut
+	}
+	out := v.FieldByName("Out")
+	if !out.IsValid() || out.Type() != reflect.TypeFor[io.Writer]() {
+		return nil, errNoOut
+	}
+
+	c := reflect.New(v.Type())
+	c.Elem().Set(v)
+	c.Elem().FieldByName("Out").Set(reflect.ValueOf(w))
+	if ptr {
+		return c.Interface().(Renderer), nil
+	}
+	return c.Elem().Interface().(Renderer), nil
+}
+
+// sizeCounter is a writer counting the bytes and lines written
+type sizeCounter struct {
+	bytes, lines int
+}
+
+func (c *sizeCounter) Write(p []byte) (int, error) {
+	c.bytes += len(p)
+	c.lines += bytes.Count(p, []byte{'\n'})
+	return len(p), nil
+}
@@ -0,0 +1,52 @@
+package tracker
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestEstimateSize(t *testing.T) {
+	// 300 checkpoints in 3 phases, only the first phase row is not hidden
+	data := testData(300, true)
+	opt := new(Options).WithName().WithDuration().WithErrors().MaxRows(10)
+
+	var out bytes.Buffer
+	tests := []struct {
+		name string
+		r    Renderer
+		rows int
+	}{
+		{"table", TableRender{Out: &out}, 22},
+		{"stream", &TableRender{Out: &out, Options: &RenderOptions{Stream: true}}, 22},
+		{"html", HTMLRender{Out: &out}, 21},
+		{"json", JSONRender{Out: &out}, 300},
+		{"csv", CSVRender{Out: &out}, 300},
+		{"markdown", MarkdownRender{Out: &out}, 300},
+		{"tap", TAPRender{Out: &out}, 300},
+		{"junit", JUnitRender{Out: &out}, 300},
+		{"har", HARRender{Out: &out}, 300},
+		{"benchstat", BenchstatRender{Out: &out}, 300},
+		{"github", GitHubRender{Out: &out}, 300},
+		{"dump", DumpRender{Out: &out}, 300},
+	}
+	for _, tt := range tests {
+		size, err := EstimateSize(tt.r, data, opt)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		out.Reset()
+		tt.r.Render(data, opt)
+
+		lines := strings.Count(out.String(), "\n")
+		if size.Bytes != out.Len() || size.Lines != lines || size.Rows != tt.rows {
+			t.Errorf("%s: got %+v, want %d bytes, %d lines and %d rows", tt.name, size, out.Len(), lines, tt.rows)
+		}
+		// the rows of a table are framed by the border and the header lines
+		if tt.name == "table" || tt.name == "stream" {
+			if size.Rows != lines-4 {
+				t.Errorf("%s: got %d rows in %d lines", tt.name, size.Rows, lines)
+			}
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package tracker
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"sync"
+	"time"
+)
+
+// wireMeta is a checkpoint streamed by a worker process
+type wireMeta struct {
+	Worker string    `json:"worker"`
+	Name   string    `json:"name"`
+	Time   time.Time `json:"time"`
+	DurNs  int64     `json:"dur_ns"`
+	Error  string    `json:"error,omitempty"`
+	File   string    `json:"file,omitempty"`
+	Line   int       `json:"line,omitempty"`
+	Pkg    string    `json:"pkg,omitempty"`
+}
+
+// streamBuffer is the number of the checkpoints queued for the writer of
+// a stream, the next ones are dropped until it catches up
+const streamBuffer = 1024
+
+var errStreamFull = errors.New("stream buffer is full, checkpoint dropped")
+
+// stream writes the checkpoints of a track in its own goroutine, so a
+// slow receiver never blocks the track
+type stream struct {
+	worker string
+	queue  chan wireMeta
+	done   chan struct{}
+	once   sync.Once
+}
+
+func newStream(w io.Writer, worker string) *stream {
+	s := &stream{
+		worker: worker,
+		queue:  make(chan wireMeta, streamBuffer),
+		done:   make(chan struct{}),
+	}
+	go s.write(w)
+	return s
+}
+
+// send queues the checkpoint, the track mutex is held
+func (s *stream) send(m Meta) {
+	w := wireMeta{
+		Worker: s.worker,
+		Name:   m.Name,
+		Time:   m.Start,
+		DurNs:  int64(m.Dur),
+		File:   m.File,
+		Line:   m.Line,
+		Pkg:    m.Pkg,
+	}
+	if m.Err != nil {
+		w.Error = m.Err.Error()
+	}
+	select {
+	case s.queue <- w:
+	default:
+		log.Printf("err:%s; error streaming checkpoint", errStreamFull.Error())
+	}
+}
+
+// write writes the queued checkpoints until the stream is closed, the
+// checkpoints after a failed write are dropped
+func (s *stream) write(out io.Writer) {
+	defer close(s.done)
+
+	enc := json.NewEncoder(out)
+	var failed bool
+	for w := range s.queue {
+		if failed {
+			continue
+		}
+		if err := enc.Encode(w); err != nil {
+			WriteFailed(out, err, "streaming checkpoint")
+			failed = true
+		}
+	}
+}
+
+// close waits until the queued checkpoints are written,
+// send must not be called after it
+func (s *stream) close() {
+	s.once.Do(func() { close(s.queue) })
+	<-s.done
+}
+
+// StreamTo makes t write every next checkpoint as a JSON line into w,
+// worker identifies the process at the receiving Collector. The lines
+// are written by a goroutine, a nil w stops the streaming once the
+// queued checkpoints are written.
+func (t *Track) StreamTo(w io.Writer, worker string) {
+	var s *stream
+	if w != nil {
+		s = newStream(w, worker)
+	}
+
+	t.mu.Lock()
+	old := t.stream
+	t.stream = s
+	t.mu.Unlock()
+
+	if old != nil {
+		old.close()
+	}
+}
+
+// DialCollector connects t to the Collector listening on the unix socket
+// at path, every next checkpoint of t is streamed to the parent process.
+// Close the returned connection when the worker is done, the queued
+// checkpoints are written before it is closed.
+func DialCollector(t *Track, path, worker string) (io.Closer, error) {
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	// the collector acknowledges the accepted connection, so a Close
+	// of the collector after this point waits for the worker
+	if _, err = io.ReadFull(conn, make([]byte, 1)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	t.StreamTo(conn, worker)
+	return collectorConn{Conn: conn, t: t}, nil
+}
+
+// collectorConn stops the streaming of the track before it is closed
+type collectorConn struct {
+	net.Conn
+	t *Track
+}
+
+func (c collectorConn) Close() error {
+	c.t.StreamTo(nil, "")
+	return c.Conn.Close()
+}
+
+// Collector merges the checkpoints streamed by worker processes on the
+// same host into one Track, for tools which fan out work across processes.
+// Merged checkpoints are named "worker/name" and keep their own timing.
+type Collector struct {
+	track *Track
+	ln    net.Listener
+	wg    sync.WaitGroup
+
+	mu    sync.Mutex
+	conns map[net.Conn]struct{}
+	// set when the remaining connections are closed by Shutdown
+	forced bool
+}
+
+// closeTimeout is how long Close waits for the connected workers
+const closeTimeout = 5 * time.Second
+
+// ListenCollector listens on the unix socket at path and merges the
+// checkpoints of the connected workers into t
+func ListenCollector(t *Track, path string) (*Collector, error) {
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Collector{track: t, ln: ln, conns: make(map[net.Conn]struct{})}
+	c.wg.Add(1)
+	go c.accept()
+	return c, nil
+}
+
+func (c *Collector) accept() {
+	defer c.wg.Done()
+	for {
+		conn, err := c.ln.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				log.Printf("err:%s; error accepting worker", err.Error())
+			}
+			return
+		}
+
+		c.mu.Lock()
+		if c.forced {
+			c.mu.Unlock()
+			conn.Close()
+			continue
+		}
+		c.conns[conn] = struct{}{}
+		c.wg.Add(1)
+		c.mu.Unlock()
+		go c.read(conn)
+	}
+}
+
+func (c *Collector) read(conn net.Conn) {
+	defer c.wg.Done()
+	defer func() {
+		c.mu.Lock()
+		delete(c.conns, conn)
+		c.mu.Unlock()
+		conn.Close()
+	}()
+
+	if _, err := conn.Write([]byte{'\n'}); err != nil {
+		log.Printf("err:%s; error acknowledging worker", err.Error())
+		return
+	}
+
+	sc := bufio.NewScanner(conn)
+	sc.Buffer(make([]byte, 0, 4096), 1<<20)
+	for sc.Scan() {
+		var w wireMeta
+		if err := json.Unmarshal(sc.Bytes(), &w); err != nil {
+			log.Printf("err:%s; error reading checkpoint", err.Error())
+			continue
+		}
+
+		m := Meta{
+			Name:  w.Worker + "/" + w.Name,
+			Start: w.Time,
+			Dur:   time.Duration(w.DurNs),
+			File:  w.File,
+			Line:  w.Line,
+			Pkg:   w.Pkg,
+		}
+		if w.Error != "" {
+			m.Err = errors.New(w.Error)
+		}
+		c.track.insert(m)
+	}
+	// the connections closed by Shutdown are not an error of the worker
+	if err := sc.Err(); err != nil && !errors.Is(err, net.ErrClosed) {
+		log.Printf("err:%s; error reading worker", err.Error())
+	}
+}
+
+// Shutdown stops accepting workers and waits until the connected ones
+// close their connections or ctx is done, then it closes the connections
+// of the remaining workers and returns the error of ctx
+func (c *Collector) Shutdown(ctx context.Context) error {
+	err := c.ln.Close()
+
+	done := make(chan struct{})
+	go func() {
+		c.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return err
+	case <-ctx.Done():
+	}
+
+	c.mu.Lock()
+	c.forced = true
+	for conn := range c.conns {
+		conn.Close()
+	}
+	c.mu.Unlock()
+	<-done
+
+	if err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// Close works as Shutdown waiting for the workers at most 5 seconds
+func (c *Collector) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+	defer cancel()
+	return c.Shutdown(ctx)
+}
@@ -0,0 +1,59 @@
+package tracker
+
+import (
+	"os"
+	"strings"
+)
+
+// SourceLine is a line of source code around the call site of a checkpoint
+type SourceLine struct {
+	Number int    `json:"n"`
+	Text   string `json:"text"`
+	// Current marks the line of the call site itself
+	Current bool `json:"current,omitempty"`
+}
+
+// sourceReader reads source context at render time,
+// every file is read only once per render
+type sourceReader struct {
+	around int
+	files  map[string][]string
+}
+
+func newSourceReader(around int) *sourceReader {
+	return &sourceReader{around: around, files: make(map[string][]string)}
+}
+
+// context returns the lines around the call site of m, or nil
+// when the call site is unknown or the file can not be read
+func (r *sourceReader) context(m Meta) []SourceLine {
+	if r.around <= 0 || m.File == "" || m.Line <= 0 {
+		return nil
+	}
+
+	lines, ok := r.files[m.File]
+	if !ok {
+		b, err := os.ReadFile(m.File)
+		if err == nil {
+			lines = strings.Split(string(b), "\n")
+		}
+		r.files[m.File] = lines
+	}
+	if m.Line > len(lines) {
+		return nil
+	}
+
+	from, to := m.Line-r.around, m.Line+r.around
+	if from < 1 {
+		from = 1
+	}
+	if to > len(lines) {
+		to = len(lines)
+	}
+
+	ctx := make([]SourceLine, 0, to-from+1)
+	for n := from; n <= to; n++ {
+		ctx = append(ctx, SourceLine{Number: n, Text: lines[n-1], Current: n == m.Line})
+	}
+	return ctx
+}
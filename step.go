@@ -0,0 +1,101 @@
+package tracker
+
+import "sync"
+
+// Step is a checkpoint enriched incrementally through a code block:
+//
+//	s := t.Step("load config")
+//	defer s.Done()
+//	s.Attr("path", path)
+//	...
+//	s.Err(err)
+//
+// The checkpoint is recorded by Done with the duration since Step.
+// It is safe for concurrent use.
+type Step struct {
+	t    *Track
+	mu   sync.Mutex
+	meta Meta
+	done bool
+}
+
+// Step starts the checkpoint with the name, it is recorded on Done
+func (t *Track) Step(name string) *Step {
+	// a zero-value track starts here, so the step does not begin before
+	// the creation checkpoint and callerSkip is set, Done reports errors
+	t.begin()
+	meta := trace(t.callerSkip)
+	meta.Name = name
+	meta.Start = t.now()
+	return &Step{t: t, meta: meta}
+}
+
+// Name renames the step, e.g. once the name is known in the block
+func (s *Step) Name(name string) *Step {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.meta.Name = name
+	return s
+}
+
+// Err sets the error of the step, a nil error is ignored so the
+// result of every call in the block can be passed
+func (s *Step) Err(err error) *Step {
+	if err == nil {
+		return s
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.meta.Err = err
+	return s
+}
+
+// Attr sets the attribute of the step
+func (s *Step) Attr(key, value string) *Step {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.meta.Attrs == nil {
+		s.meta.Attrs = make(map[string]string)
+	}
+	s.meta.Attrs[key] = value
+	return s
+}
+
+// Note appends the note to the step
+func (s *Step) Note(note string) *Step {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.meta.Notes = append(s.meta.Notes, note)
+	return s
+}
+
+// Pin pins the step, see UpdatePinned
+func (s *Step) Pin() *Step {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.meta.Pinned = true
+	return s
+}
+
+// Done records the step into the track, the next calls do nothing
+func (s *Step) Done() error {
+	if ok, err := s.t.begin(); !ok {
+		return err
+	}
+
+	s.mu.Lock()
+	if s.done {
+		s.mu.Unlock()
+		return nil
+	}
+	s.done = true
+	meta := s.meta
+	s.mu.Unlock()
+
+	// the checkpoint is placed at the end of the step like an Update
+	end := s.t.now()
+	meta.Dur = end.Sub(meta.Start)
+	meta.Start = end
+	s.t.insert(meta)
+	return nil
+}
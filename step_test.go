@@ -0,0 +1,92 @@
+package tracker
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// checkEnd checks that m is placed at the end of its step like an
+// Update: Start is the end, StartDif-Dur the beginning
+func checkEnd(t *testing.T, m Meta, begin, dur time.Duration) {
+	t.Helper()
+	if m.Dur != dur {
+		t.Errorf("%s: got dur %v, want %v", m.Name, m.Dur, dur)
+	}
+	if m.StartDif != begin+dur || !m.Start.Equal(simStart.Add(begin+dur)) {
+		t.Errorf("%s: got start %v since start %v, want the end %v", m.Name, m.Start, m.StartDif, begin+dur)
+	}
+}
+
+func TestStepDoneAtEnd(t *testing.T) {
+	tr, clock := simTrack(t)
+	clock.Advance(time.Second)
+
+	s := tr.Step("load")
+	clock.Advance(5 * time.Millisecond)
+	s.Done()
+	checkEnd(t, last(t, tr), time.Second, 5*time.Millisecond)
+
+	s.Done()
+	if n := tr.Len(); n != 2 {
+		t.Errorf("got %d checkpoints after Done twice, want 2", n)
+	}
+}
+
+func TestStepZeroValue(t *testing.T) {
+	var tr Track
+	clock := NewSimClock(simStart, 0)
+	if err := tr.SetClock(clock); err != nil {
+		t.Fatal(err)
+	}
+
+	s := tr.Step("load")
+	clock.Advance(5 * time.Millisecond)
+	if err := s.Done(); err != nil {
+		t.Fatal(err)
+	}
+
+	m := last(t, &tr)
+	checkEnd(t, m, 0, 5*time.Millisecond)
+	if !strings.HasSuffix(m.File, "step_test.go") {
+		t.Errorf("got the call site %s:%d, want step_test.go", m.File, m.Line)
+	}
+}
+
+func TestHookAtEnd(t *testing.T) {
+	tr, clock := simTrack(t)
+	ctx := NewContext(context.Background(), tr)
+	clock.Advance(time.Second)
+
+	done := Hook(ctx, "redis", "get", "key", "user:1")
+	clock.Advance(3 * time.Millisecond)
+	done(errors.New("miss"))
+
+	m := last(t, tr)
+	checkEnd(t, m, time.Second, 3*time.Millisecond)
+	if m.Name != "redis get" || m.Err == nil || m.Attrs["system"] != "redis" || m.Attrs["key"] != "user:1" {
+		t.Errorf("got %+v", m)
+	}
+}
+
+func TestWrapAtEnd(t *testing.T) {
+	tr, clock := simTrack(t)
+	clock.Advance(time.Second)
+
+	errFetch := errors.New("fetch")
+	fetch := Wrap(tr, func(d time.Duration) error {
+		clock.Advance(d)
+		return errFetch
+	})
+	if err := fetch(7 * time.Millisecond); err != errFetch {
+		t.Fatalf("got %v, want the error of the func", err)
+	}
+
+	m := last(t, tr)
+	checkEnd(t, m, time.Second, 7*time.Millisecond)
+	if m.Err != errFetch {
+		t.Errorf("got error %v, want %v", m.Err, errFetch)
+	}
+}
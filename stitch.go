@@ -0,0 +1,162 @@
+package tracker
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// Export is a track saved by one service taking part in a distributed
+// operation, services sharing the trace ID can be stitched together offline
+type Export struct {
+	Service     string                `json:"service"`
+	TraceID     string                `json:"trace_id"`
+	Checkpoints []DashboardCheckpoint `json:"checkpoints"`
+	// Build is the build of the service if it was captured, see CaptureBuild
+	Build *Build `json:"build,omitempty"`
+}
+
+// Export returns the current state of the track as an Export
+// of the service within the trace, encode it with encoding/json
+func (t *Track) Export(service, traceID string) Export {
+	return Export{
+		Service:     service,
+		TraceID:     traceID,
+		Checkpoints: t.snapshot().Dashboard().Checkpoints,
+		Build:       t.Build(),
+	}
+}
+
+// MetaData converts the exported checkpoints back
+func (e Export) MetaData() MetaData {
+	data := make(MetaData, 0, len(e.Checkpoints))
+	for _, c := range e.Checkpoints {
+		m := Meta{
+			Name:     c.Name,
+			Start:    c.Time,
+			Dur:      time.Duration(c.DurationNs),
+			StartDif: time.Duration(c.OffsetNs),
+			Seq:      uint64(c.Index),
+		}
+		if c.Error != "" {
+			m.Err = errors.New(c.Error)
+		}
+		data = append(data, m)
+	}
+	return data
+}
+
+// Lane is the part of a stitched trace made by one service
+type Lane struct {
+	Service string
+	Data    MetaData
+}
+
+// Stitched is a trace stitched from the tracks of several services,
+// StartDif of every checkpoint is relative to the Start of the trace
+type Stitched struct {
+	TraceID string
+	Start   time.Time
+	Lanes   []Lane
+}
+
+// Stitch groups the exports by trace ID and puts the tracks of every trace
+// on a common time axis, one lane per service ordered by the start time.
+// It approximates distributed tracing offline: the clocks of the hosts
+// are assumed to be in sync.
+func Stitch(exports []Export) []Stitched {
+	var traces []Stitched
+	index := make(map[string]int)
+
+	for _, e := range exports {
+		data := e.MetaData()
+		if len(data) == 0 {
+			continue
+		}
+
+		i, ok := index[e.TraceID]
+		if !ok {
+			i = len(traces)
+			index[e.TraceID] = i
+			traces = append(traces, Stitched{TraceID: e.TraceID, Start: data[0].Start})
+		}
+		if data[0].Start.Before(traces[i].Start) {
+			traces[i].Start = data[0].Start
+		}
+		traces[i].Lanes = append(traces[i].Lanes, Lane{Service: e.Service, Data: data})
+	}
+
+	for i := range traces {
+		s := &traces[i]
+		for _, l := range s.Lanes {
+			for j := range l.Data {
+				l.Data[j].StartDif = l.Data[j].Start.Sub(s.Start)
+			}
+		}
+		sort.SliceStable(s.Lanes, func(a, b int) bool {
+			return s.Lanes[a].Data[0].Start.Before(s.Lanes[b].Data[0].Start)
+		})
+	}
+	return traces
+}
+
+// End returns the time of the last checkpoint of the trace relative to its start
+func (s Stitched) End() time.Duration {
+	var end time.Duration
+	for _, l := range s.Lanes {
+		if d := l.Data[len(l.Data)-1].StartDif; d > end {
+			end = d
+		}
+	}
+	return end
+}
+
+// RenderStitched renders a stitched trace as a hierarchical table: a row for
+// every service lane followed by its checkpoints, with a waterfall column
+// on the time axis of the whole trace
+func (tbr TableRender) RenderStitched(s Stitched) {
+	out := capture(tbr.Out)
+	table := tablewriter.NewWriter(out)
+	table.SetHeader([]string{"trace " + s.TraceID, "since.start", "duration", "errors", "track"})
+
+	total := s.End()
+	ro := RenderOptions{Divider: 40}
+	if tbr.Options != nil && tbr.Options.Divider > 0 {
+		ro.Divider = tbr.Options.Divider
+	}
+
+	for _, l := range s.Lanes {
+		first, last := l.Data[0], l.Data[len(l.Data)-1]
+		span := Meta{StartDif: last.StartDif, Dur: last.StartDif - first.StartDif}
+		table.Append([]string{
+			l.Service,
+			first.StartDif.String(),
+			span.Dur.String(),
+			"",
+			strings.ReplaceAll(ro.waterfall(span, total), "*", "="),
+		})
+
+		// the first elem is the start of the service track, it is its lane row
+		for _, m := range l.Data[1:] {
+			var errText string
+			if m.Err != nil {
+				errText = m.Err.Error()
+			}
+			table.Append([]string{
+				"  " + m.Name,
+				m.StartDif.String(),
+				m.Dur.String(),
+				errText,
+				ro.waterfall(m, total),
+			})
+		}
+	}
+
+	table.Render()
+	if err := out.Err(); err != nil {
+		WriteFailed(tbr.Out, err, "writing data")
+	}
+}
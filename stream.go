@@ -0,0 +1,141 @@
+package tracker
+
+import (
+	"bufio"
+	"strings"
+	"unicode/utf8"
+)
+
+// stream writes the table in the layout of the tablewriter package,
+// but row by row with a single reused row buffer
+func (tbr TableRender) stream(data MetaData, opt *Options) {
+	headers := createHeaders(make([]string, 0, 10), opt)
+	timeLine := tbr.Options.timeLine(data)
+	formats := tbr.Options.formats(data)
+	phases := phaseRows(data, opt, formats)
+	links := tbr.Options.hyperlinks(data, opt)
+	overflow := newOverflow(data, opt, formats)
+
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		headers[i] = strings.ToUpper(strings.NewReplacer(".", " ", "_", " ").Replace(h))
+		widths[i] = utf8.RuneCountInString(headers[i])
+	}
+
+	row := make([]string, 0, len(headers))
+	for i, m := range data {
+		if overflow.hides(i) {
+			continue
+		}
+		row = createRow(row[:0], opt, m, timeLine(m), formats)
+		fitWidths(widths, row)
+	}
+	for i, p := range phases {
+		if !overflow.hides(i) {
+			fitWidths(widths, p)
+		}
+	}
+	for _, p := range overflow.rows {
+		fitWidths(widths, p)
+	}
+
+	w := bufio.NewWriter(tbr.Out)
+	line := streamLine(widths)
+
+	w.WriteString(line)
+	for i, h := range headers {
+		pad := widths[i] - utf8.RuneCountInString(h)
+		w.WriteString("| ")
+		w.WriteString(strings.Repeat(" ", pad/2))
+		w.WriteString(h)
+		w.WriteString(strings.Repeat(" ", pad-pad/2+1))
+	}
+	w.WriteString("|\n")
+	w.WriteString(line)
+
+	for i, m := range data {
+		if p, ok := overflow.rows[i]; ok {
+			writeStreamRow(w, p, widths, nil)
+		}
+		if overflow.hides(i) {
+			continue
+		}
+		if p, ok := phases[i]; ok {
+			writeStreamRow(w, p, widths, nil)
+		}
+		row = createRow(row[:0], opt, m, timeLine(m), formats)
+		writeStreamRow(w, row, widths, links)
+	}
+	w.WriteString(line)
+	if caption := tbr.Options.caption(data); caption != "" {
+		w.WriteString(caption + "\n")
+	}
+
+	if err := w.Flush(); err != nil {
+		WriteFailed(tbr.Out, err, "writing data")
+	}
+}
+
+func fitWidths(widths []int, row []string) {
+	for i, cell := range row {
+		if n := utf8.RuneCountInString(cell); n > widths[i] {
+			widths[i] = n
+		}
+	}
+}
+
+// writeStreamRow writes the row padded to the widths, links replaces
+// the links with hyperlinks after the padding if it is not nil
+func writeStreamRow(w *bufio.Writer, row []string, widths []int, links *strings.Replacer) {
+	var b strings.Builder
+	for i, cell := range row {
+		pad := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell))
+		b.WriteString("| ")
+		if isNumber(cell) {
+			b.WriteString(pad)
+			b.WriteString(cell)
+			b.WriteString(" ")
+		} else {
+			b.WriteString(cell)
+			b.WriteString(pad)
+			b.WriteString(" ")
+		}
+	}
+	b.WriteString("|\n")
+
+	if links != nil {
+		links.WriteString(w, b.String())
+		return
+	}
+	w.WriteString(b.String())
+}
+
+// streamLine returns the border line like +------+-----+
+func streamLine(widths []int) string {
+	var b strings.Builder
+	for _, w := range widths {
+		b.WriteString("+")
+		b.WriteString(strings.Repeat("-", w+2))
+	}
+	b.WriteString("+\n")
+	return b.String()
+}
+
+// reports whether the cell is a number, tablewriter aligns them to the right
+func isNumber(s string) bool {
+	s = strings.TrimPrefix(s, "-")
+	if s == "" || s[0] < '0' || s[0] > '9' {
+		return false
+	}
+
+	dot := false
+	for _, r := range s {
+		switch {
+		case r == '.' && !dot:
+			dot = true
+		case r < '0' || r > '9':
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,81 @@
+package tracker
+
+import (
+	"fmt"
+	"time"
+)
+
+// ExitPolicy maps the result of a track into a process exit code
+// for shell scripts wrapping tracked Go tools. A zero code disables a check.
+type ExitPolicy struct {
+	// ErrorCode is returned when any checkpoint has an error
+	ErrorCode int
+	// BudgetCode is returned when any checkpoint exceeds its budget
+	// or the whole track is longer than Total
+	BudgetCode int
+	Budgets    Budgets
+	Total      time.Duration
+}
+
+// ExitCode returns the exit code for the tracked data by the policy,
+// errors take precedence over budget breaches
+func (t *Track) ExitCode(policy ExitPolicy) int {
+	data := t.snapshot()
+
+	var errs, breaches int
+	for _, m := range data {
+		if m.Err != nil {
+			errs++
+		}
+		if policy.Budgets.Exceeded(m) {
+			breaches++
+		}
+	}
+	if policy.Total > 0 && len(data) > 0 && data[len(data)-1].StartDif > policy.Total {
+		breaches++
+	}
+
+	switch {
+	case errs > 0 && policy.ErrorCode != 0:
+		return policy.ErrorCode
+	case breaches > 0 && policy.BudgetCode != 0:
+		return policy.BudgetCode
+	}
+	return 0
+}
+
+// Summary returns the single line summary of the tracked data
+func (t *Track) Summary() string {
+	return t.snapshot().Summary()
+}
+
+// Summary returns a compact single line summary like
+// "12 checkpoints, 3 errors, total 4.2s, max step 1.8s"
+func (m MetaData) Summary() string {
+	if len(m) == 0 {
+		return "0 checkpoints"
+	}
+
+	var errs int
+	for _, e := range m {
+		if e.Err != nil {
+			errs++
+		}
+	}
+
+	return fmt.Sprintf("%d checkpoints, %d errors, total %s, max step %s",
+		len(steps(m)), errs, round(m[len(m)-1].StartDif), round(m.MaxDuration()))
+}
+
+// round keeps one decimal of the largest unit of d
+func round(d time.Duration) time.Duration {
+	switch {
+	case d >= time.Second:
+		return d.Round(100 * time.Millisecond)
+	case d >= time.Millisecond:
+		return d.Round(100 * time.Microsecond)
+	case d >= time.Microsecond:
+		return d.Round(100 * time.Nanosecond)
+	}
+	return d
+}
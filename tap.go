@@ -0,0 +1,43 @@
+package tracker
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// TAPRender writes the track in Test Anything Protocol (version 13):
+// one test point per checkpoint, "not ok" when an error was sent into
+// Update() or the checkpoint exceeds its budget. Durations go into the
+// YAML diagnostics of each test point.
+type TAPRender struct {
+	Out     io.Writer
+	Budgets Budgets
+}
+
+func (tpr TAPRender) Render(data MetaData, opt *Options) {
+	var buf bytes.Buffer
+
+	points := steps(data)
+	fmt.Fprintf(&buf, "TAP version 13\n1..%d\n", len(points))
+	for i, m := range points {
+		status := "ok"
+		if m.Err != nil || tpr.Budgets.Exceeded(m) {
+			status = "not ok"
+		}
+		fmt.Fprintf(&buf, "%s %d - %s\n", status, i+1, m.Name)
+		fmt.Fprintf(&buf, "  ---\n  duration: %s\n  since_start: %s\n", m.Dur, m.StartDif)
+		if b := tpr.Budgets.Of(m.Name); b > 0 {
+			fmt.Fprintf(&buf, "  budget: %s\n", b)
+		}
+		if m.Err != nil {
+			fmt.Fprintf(&buf, "  error: %s\n", strconv.Quote(m.Err.Error()))
+		}
+		buf.WriteString("  ...\n")
+	}
+
+	if _, err := buf.WriteTo(tpr.Out); err != nil {
+		WriteFailed(tpr.Out, err, "writing data")
+	}
+}
@@ -0,0 +1,14 @@
+module github.com/cat-in-vacuum/tracker/trackconnect
+
+go 1.25.0
+
+require (
+	connectrpc.com/connect v1.19.1
+	github.com/cat-in-vacuum/tracker v0.0.0-20261016092754-149836cefd76
+)
+
+require (
+	github.com/mattn/go-runewidth v0.0.9 // indirect
+	github.com/olekukonko/tablewriter v0.0.5 // indirect
+	google.golang.org/protobuf v1.36.9 // indirect
+)
@@ -0,0 +1,122 @@
+// Package trackconnect tracks the calls of Connect RPC handlers and
+// clients with the same per-call Track lifecycle as the HTTP middleware:
+// every handled call gets its own tracker.Track carried by the context.
+package trackconnect
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/cat-in-vacuum/tracker"
+)
+
+// Interceptor is a connect.Interceptor recording every call as a
+// checkpoint named by the procedure with the attributes code, protocol
+// and peer. A handled call gets a new track created by Factory, the
+// handlers get it with tracker.FromContext. A client call is recorded
+// into the track of its context, if any.
+//
+//	path, handler := foov1connect.NewFooServiceHandler(svc,
+//		connect.WithInterceptors(trackconnect.NewInterceptor(f)),
+//	)
+type Interceptor struct {
+	// Factory creates the tracks of the handled calls
+	Factory *tracker.Factory
+	// Done is called with the finished track of every handled call if set
+	Done func(ctx context.Context, procedure string, t *tracker.Track)
+}
+
+var _ connect.Interceptor = (*Interceptor)(nil)
+
+func NewInterceptor(f *tracker.Factory) *Interceptor {
+	return &Interceptor{Factory: f}
+}
+
+func (i *Interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		ctx, t, handler := i.track(ctx, req.Spec())
+		if t == nil {
+			return next(ctx, req)
+		}
+
+		s := step(t, req.Spec(), req.Peer())
+		res, err := next(ctx, req)
+		done(s, err)
+
+		if handler && i.Done != nil {
+			i.Done(ctx, req.Spec().Procedure, t)
+		}
+		return res, err
+	}
+}
+
+func (i *Interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		conn := next(ctx, spec)
+		t := tracker.FromContext(ctx)
+		if t == nil {
+			return conn
+		}
+		return &clientConn{StreamingClientConn: conn, step: step(t, spec, conn.Peer())}
+	}
+}
+
+func (i *Interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		ctx, t, _ := i.track(ctx, conn.Spec())
+		if t == nil {
+			return next(ctx, conn)
+		}
+
+		s := step(t, conn.Spec(), conn.Peer())
+		err := next(ctx, conn)
+		done(s, err)
+
+		if i.Done != nil {
+			i.Done(ctx, conn.Spec().Procedure, t)
+		}
+		return err
+	}
+}
+
+// track returns the track of the call: a new one for a handled call,
+// the one of the context for a client call
+func (i *Interceptor) track(ctx context.Context, spec connect.Spec) (context.Context, *tracker.Track, bool) {
+	if spec.IsClient {
+		return ctx, tracker.FromContext(ctx), false
+	}
+	f := i.Factory
+	if f == nil {
+		f = tracker.NewFactory(3)
+	}
+	ctx, t := f.NewTrack(ctx)
+	return ctx, t, true
+}
+
+func step(t *tracker.Track, spec connect.Spec, peer connect.Peer) *tracker.Step {
+	return t.Step(spec.Procedure).
+		Attr("protocol", peer.Protocol).
+		Attr("peer", peer.Addr)
+}
+
+// done records the step with the code of the call error
+func done(s *tracker.Step, err error) {
+	code := "ok"
+	if err != nil {
+		code = connect.CodeOf(err).String()
+	}
+	s.Attr("code", code).Err(err).Done()
+}
+
+// clientConn records the streaming call when the response is closed,
+// the step is recorded once however many times it is closed
+type clientConn struct {
+	connect.StreamingClientConn
+	step *tracker.Step
+}
+
+func (c *clientConn) CloseResponse() error {
+	err := c.StreamingClientConn.CloseResponse()
+	done(c.step, err)
+	return err
+}
@@ -1,20 +1,31 @@
 package tracker
 
 import (
-	"encoding/json"
+	"bufio"
+	"bytes"
 	"errors"
 	"fmt"
 	"github.com/olekukonko/tablewriter"
 	"io"
-	"log"
+	"math"
+	"os"
 	"runtime"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
 var (
 	msgFormat = "function:[%s]|sinceStart:[%s]|duration:[%s]|"
+
+	errNotStarted = errors.New("at first need to invoke New(int)")
 )
 
+// defaultCallerSkip makes trace resolve the caller of the API function
+// it is called from, it is the callerSkip of an auto started Track
+const defaultCallerSkip = 3
+
 // Renderer track trace must implement Render() , but should not be aware of the output.
 // In this package are implemented two Renderer`s  - table render and json render,
 // but you can use other.
@@ -23,16 +34,82 @@ type Renderer interface {
 }
 
 // the track is
+//
+// A Track can be embedded into a struct to give it the tracking methods,
+// the promoted Update resolves the caller of the struct method just like
+// a direct call. The zero value is ready to use: it starts on the first
+// Update and renders as a table into stdout with the name, since.start,
+// duration and errors columns.
+//
+//	type Importer struct {
+//		tracker.Track
+//	}
+//
+//	imp := &Importer{}
 type Track struct {
+	// Data is the raw storage of the checkpoints, writes into it
+	// break the invariants of the track. It stays empty once the
+	// track is chunked (see SetChunkSize), the accessors, the
+	// renderers and the JSON encoding of the track read either.
+	//
+	// Deprecated: use the read-only accessors Get, Len, Snapshot or All,
+	// Data is going to be unexported in the next major version.
 	Data          MetaData `json:"trackedData,omitempty"`
 	Loggable      bool
 	callerSkip    int
+	depth         int
+	maxDepth      int
 	messageFormat string
 	options       *Options
 	Renderer
+
+	// Strict makes a Track which was not created by New return an error
+	// from Update instead of starting on it, the creation checkpoint of
+	// other tracks is recorded by their first Update.
+	Strict bool
+
+	// the sequence number of the last checkpoint
+	seq uint64
+	// blocking time since the last checkpoint, see Wait()
+	blocked time.Duration
+	// the storage of checkpoints instead of Data, see SetChunkSize()
+	chunks *chunks
+	// receives every checkpoint, see StreamTo()
+	stream *stream
+	// see SetLabel()
+	labels map[string]string
+	// the time of the last checkpoint made by Update, kept even if
+	// the checkpoint was not sampled, so the next duration is right
+	last    time.Time
+	sampler Sampler
+	// see SetAverages()
+	averages *averages
+	// see SetHistograms()
+	histograms map[string]*Histogram
+	// see SetWarnings()
+	warnings *Warnings
+	// the current phase, see Phase()
+	phase string
+	// see SetClock()
+	clock     Clock
+	simulated bool
+	// see SetIgnore()
+	ignore *ignore
+	// see SetRewrites()
+	rewrites []Rewrite
+	// see CaptureBuild()
+	build *Build
+	// see SetEnabled()
+	disabled bool
+	// the aggregates of the folded checkpoints, see Compact()
+	compacted      []Group
+	compactedIndex map[string]int
+	// guards the storage against checkpoints recorded while it is read
+	mu sync.Mutex
 }
 
-// contains meta information about current function
+// contains meta information about current function,
+// JSONRender encodes it by hand (see appendMeta), keep them in sync
 type MetaData []Meta
 type Meta struct {
 	Name     string        `json:"name"`
@@ -40,6 +117,26 @@ type Meta struct {
 	Dur      time.Duration `json:"dur"`
 	StartDif time.Duration `json:"start_dif"`
 	Err      error         `json:"error"`
+	File     string        `json:"file,omitempty"`
+	Line     int           `json:"line,omitempty"`
+	// Pkg is the import path of the package of the caller, see Package
+	Pkg string `json:"pkg,omitempty"`
+	// Seq is the number of the checkpoint in its track, it is never
+	// reused, so rows can be referenced across exports
+	Seq uint64 `json:"seq"`
+	// ID is the correlation ID given by the user, see UpdateWithID
+	ID string `json:"id,omitempty"`
+	// Blocked is the part of Dur spent in the wait helpers (Wait, Recv...)
+	Blocked time.Duration `json:"blocked,omitempty"`
+	// Attrs and Notes enrich the checkpoint, see Step
+	Attrs map[string]string `json:"attrs,omitempty"`
+	Notes []string          `json:"notes,omitempty"`
+	// Phase is the phase of the track the checkpoint was made in, see Phase
+	Phase string `json:"phase,omitempty"`
+	// Simulated is set if the checkpoint was made by a simulated clock
+	Simulated bool `json:"simulated,omitempty"`
+	// Pinned checkpoints are never dropped from compact views, see UpdatePinned
+	Pinned bool `json:"pinned,omitempty"`
 }
 
 // leverage of options for build info
@@ -48,13 +145,21 @@ type Meta struct {
 // withSinceStart -  will add duration of since creation instance of Track
 // withDuration - will add a duration since previous call Update()
 // withTrack - will add a string which  visualize the called function duration
+// withLink - will add the file:line where the checkpoint was made
+// withSeq - will add the sequence number of the checkpoint
+// withID - will add the correlation ID sent into UpdateWithID()
+// withBusy - will add the duration without the time blocked in the wait helpers
 type Options struct {
 	withErrors,
 	withName,
 	withSinceStart,
 	withDuration,
 	withTrack,
-	withLink bool
+	withLink,
+	withSeq,
+	withID,
+	withBusy bool
+	maxRows int
 }
 
 func (t *Track) SetMessageFormat(s string) {
@@ -64,44 +169,267 @@ func (t *Track) SetMessageFormat(s string) {
 func New(callerSkip int) *Track {
 	t := Track{
 		callerSkip: callerSkip,
+		depth:      stackDepth(),
 	}
-	t.Data = append(t.Data, Meta{
-		Start: time.Now(),
-		Name:  trace(t.callerSkip),
-	})
+	t.start(trace(t.callerSkip))
+	return &t
+}
 
-	if t.Loggable {
-		fmt.Println(t.Data[0].info())
+// CloneConfig returns a fresh Track with the same options, renderer,
+// message format, labels and logging as t, but without its tracked data.
+// Useful for stamping out per-request tracks from a configured prototype.
+func (t *Track) CloneConfig() *Track {
+	c := Track{
+		Loggable:      t.Loggable,
+		callerSkip:    t.callerSkip,
+		depth:         stackDepth(),
+		maxDepth:      t.maxDepth,
+		messageFormat: t.messageFormat,
+		Renderer:      t.Renderer,
+		labels:        t.Labels(),
+		sampler:       t.sampler,
+		ignore:        t.ignore,
+		rewrites:      t.rewrites,
+		clock:         t.clock,
+		simulated:     t.simulated,
 	}
-	return &t
+	if t.options != nil {
+		opt := *t.options
+		c.options = &opt
+	}
+	if t.chunks != nil {
+		c.chunks = newChunks(t.chunks.size)
+	}
+	if t.averages != nil {
+		c.averages = &averages{alpha: t.averages.alpha, byName: make(map[string]*Average)}
+	}
+	if t.histograms != nil {
+		c.histograms = make(map[string]*Histogram)
+	}
+	if t.warnings != nil {
+		w := *t.warnings
+		c.warnings = &w
+	}
+	c.start(trace(c.callerSkip))
+	return &c
 }
 
-// Track.Update() append elem into t.Data which contain the invoke time ,
+// Track.Update() append elem into the storage which contain the invoke time ,
 // duration since of previous invoke, name of function who call Update()
 func (t *Track) Update(err error) error {
-	if len(t.Data) < 1 {
-		return errors.New("at first need to invoke New(int)")
+	if ok, err := t.begin(); !ok {
+		return err
+	}
+
+	meta := trace(t.callerSkip)
+	meta.Err = err
+	t.add(meta, "")
+
+	return nil
+}
+
+// UpdateWithID works as Update() and attaches a correlation ID
+// (request ID, job ID and so on) to the checkpoint
+func (t *Track) UpdateWithID(id string, err error) error {
+	if ok, err := t.begin(); !ok {
+		return err
 	}
 
-	meta := Meta{
-		Name:     trace(t.callerSkip),
-		Start:    time.Now(),
-		Dur:      t.Data[len(t.Data)-1].Since(),
-		StartDif: t.Data[0].Since(),
-		Err:      err,
+	meta := trace(t.callerSkip)
+	meta.ID = id
+	meta.Err = err
+	t.add(meta, "")
+
+	return nil
+}
+
+// begin is the preamble of the API functions recording a checkpoint and
+// must be called by them directly: it starts a zero-value track unless it
+// is Strict, ok is false if the checkpoint is not to be recorded because
+// of the error or SetMaxDepth
+func (t *Track) begin() (ok bool, err error) {
+	// the frame of begin is not counted
+	if !t.started() {
+		if t.Strict {
+			return false, errNotStarted
+		}
+		t.autoStart(trace(defaultCallerSkip+1), stackDepth()-1)
+	}
+	if t.maxDepth > 0 && stackDepth()-1-t.depth > t.maxDepth {
+		return false, nil
+	}
+	return true, nil
+}
+
+// autoStart records the creation checkpoint of a zero-value Track,
+// unless another goroutine has already done it
+func (t *Track) autoStart(start Meta, depth int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.len() > 0 {
+		return
 	}
 
-	t.Data = append(t.Data, meta)
+	if t.callerSkip == 0 {
+		t.callerSkip = defaultCallerSkip
+	}
+	t.depth = depth
+	t.start(start)
+}
+
+// start records the creation checkpoint at the time of the clock of t
+// and captures the build, t.mu must be held unless t is not shared yet
+func (t *Track) start(start Meta) {
+	t.build = captureBuild()
+	start.Start = t.nowLocked()
+	start.Simulated = t.simulated
+	t.push(start)
 
 	if t.Loggable {
-		fmt.Println(meta.info())
+		fmt.Println(start.info())
 	}
+}
 
-	return nil
+// add appends the checkpoint into the storage, the duration
+// is measured since the previous checkpoint. The prefix of
+// a Scope is put before the name once it is rewritten.
+func (t *Track) add(meta Meta, prefix string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.admit(&meta, prefix) {
+		return
+	}
+
+	if t.clock == nil {
+		meta.Start = time.Now()
+		if t.last.IsZero() {
+			meta.Dur = t.at(t.len() - 1).Since()
+		} else {
+			meta.Dur = meta.Start.Sub(t.last)
+		}
+		meta.StartDif = t.at(0).Since()
+	} else {
+		meta.Start = t.clock.Now()
+		last := t.last
+		if last.IsZero() {
+			last = t.at(t.len() - 1).Start
+		}
+		meta.Dur = meta.Start.Sub(last)
+		meta.StartDif = meta.Start.Sub(t.at(0).Start)
+	}
+	meta.Blocked = t.blocked
+	t.blocked = 0
+	t.last = meta.Start
+
+	if !t.keep(meta) {
+		return
+	}
+
+	t.commit(meta)
+}
+
+// insert appends the checkpoint of a step measured by the caller, e.g.
+// by Step or Record, its Start and Dur are kept. It goes through the
+// same ignore rules, rewrites, sampler and rollups as add, but the
+// prefix of a Scope does not apply and the checkpoint does not move
+// t.last: an Update is still measured since the previous Update.
+func (t *Track) insert(meta Meta) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.admit(&meta, "") {
+		return
+	}
+
+	if t.len() > 0 {
+		meta.StartDif = meta.Start.Sub(t.at(0).Start)
+	}
+
+	if !t.keep(meta) {
+		return
+	}
+	t.commit(meta)
+}
+
+// admit applies the ignore rules and the rewrites to the checkpoint,
+// it reports false if the checkpoint is dropped, t.mu must be held
+func (t *Track) admit(meta *Meta, prefix string) bool {
+	if t.disabled || t.ignore.match(meta.Name) {
+		return false
+	}
+	meta.Name = prefix + rewrite(t.rewrites, meta.Name)
+	return true
+}
+
+// keep feeds the rollups with the checkpoint and reports whether the
+// sampler keeps it in the storage, t.mu must be held
+func (t *Track) keep(meta Meta) bool {
+	if t.averages != nil {
+		t.averages.add(meta)
+	}
+	if t.histograms != nil {
+		t.record(meta)
+	}
+	return t.sampler == nil || meta.Pinned || t.sampler.Sample(meta)
+}
+
+// commit numbers the checkpoint and stores it, t.mu must be held
+func (t *Track) commit(meta Meta) {
+	t.seq++
+	meta.Seq = t.seq
+	if meta.Phase == "" {
+		meta.Phase = t.phase
+	}
+	meta.Simulated = t.simulated
+
+	t.push(meta)
+
+	if t.Loggable {
+		fmt.Println(meta.info())
+		if warn := t.warn(meta); warn != "" {
+			fmt.Println(warn)
+		}
+	}
+	if t.stream != nil {
+		t.stream.send(meta)
+	}
 }
 
 type RenderOptions struct {
 	Divider int
+	// LogScale maps durations to the track bar on a log scale, so a single
+	// outlier does not flatten all the other bars to zero characters
+	LogScale bool
+	// Waterfall starts the bar of every row at the offset of the step from
+	// the start of the track, the width of the track column is the whole
+	// track then. LogScale does not apply to the waterfall.
+	Waterfall bool
+	// Stream makes TableRender write rows as they are built instead of
+	// collecting the whole table first, it is meant for huge tracks:
+	// columns are sized in a first pass and cells are never wrapped
+	Stream bool
+	// AutoUnit formats every duration column in a single unit picked by the
+	// largest value of the column: ns, µs, ms, s, or m:ss for minutes
+	AutoUnit bool
+	// Humanize writes the durations in words of the catalog language,
+	// e.g. LookupCatalog("de"), it takes precedence over AutoUnit
+	Humanize *Catalog
+	// LinkTemplate makes the links of the link column OSC 8 terminal
+	// hyperlinks: the URL is the template with {path} and {line} replaced
+	// by the call site, e.g. "vscode://file{path}:{line}"
+	LinkTemplate string
+	// NumericUnit makes CSVRender and JSONRender write durations as plain
+	// numbers of the unit instead of duration strings, e.g. time.Millisecond,
+	// the unit is noted in the headers and field names: "duration (ms)",
+	// "dur_ms". Spreadsheet formulas can't parse strings like "1.2034s".
+	NumericUnit time.Duration
+	// Numbers separates the thousands of the counts, e.g. the seq column,
+	// and sets the decimal separator of AutoUnit durations in the locale,
+	// e.g. LookupNumberFormat("de")
+	Numbers *NumberFormat
+	// Footer is a line written under the table, e.g. the cumulative
+	// totals of the delta renders of RenderEvery
+	Footer string
 }
 
 type TableRender struct {
@@ -141,49 +469,192 @@ func (iter Meta) Since() time.Duration {
 	return time.Since(iter.Start)
 }
 
+// Link returns the file:line where the checkpoint was made
+func (iter Meta) Link() string {
+	if iter.File == "" {
+		return ""
+	}
+	return iter.File + ":" + strconv.Itoa(iter.Line)
+}
+
 func (iter Meta) info() string {
 	return fmt.Sprintf(msgFormat, iter.Name, iter.StartDif, iter.Dur, )
 }
 
 func (tbr TableRender) Render(data MetaData, opt *Options) {
-	headers := make([]string, 0, len(data))
+	if tbr.Options == nil {
+		tbr.Options = &RenderOptions{}
+	}
+	if opt == nil {
+		opt = defaultOptions()
+	}
+	if tbr.Options.Stream {
+		tbr.stream(data, opt)
+		return
+	}
+
+	out := tbr.Out
+	links := tbr.Options.hyperlinks(data, opt)
+	var buf bytes.Buffer
+	if links != nil {
+		out = &buf
+	}
+
+	headers := make([]string, 0, 10)
 	headers = createHeaders(headers, opt)
-	table := tablewriter.NewWriter(tbr.Out)
+	cw := capture(out)
+	table := tablewriter.NewWriter(cw)
 	table.SetHeader(headers)
 
-	for i, v := range data {
-		var timeLine string
-
-		if v.Err == nil {
-			v.Err = errors.New("")
+	timeLine := tbr.Options.timeLine(data)
+	formats := tbr.Options.formats(data)
+	phases := phaseRows(data, opt, formats)
+	overflow := newOverflow(data, opt, formats)
+	for i := range data {
+		if row, ok := overflow.rows[i]; ok {
+			table.Append(row)
 		}
+		if overflow.hides(i) {
+			continue
+		}
+		if row, ok := phases[i]; ok {
+			table.Append(row)
+		}
+		row := createRow(make([]string, 0, len(headers)), opt, data[i], timeLine(data[i]), formats)
+
+		table.Append(row)
+	}
 
-		step := int(data.MaxDuration()) / tbr.Options.Divider
+	if caption := tbr.Options.caption(data); caption != "" {
+		table.SetCaption(true, caption)
+	}
+	table.Render()
+	if err := cw.Err(); err != nil {
+		WriteFailed(tbr.Out, err, "writing data")
+		return
+	}
 
-		for k := 0; k < int(data[i].Dur); k += step {
-			timeLine = timeLine + "*"
+	if links != nil {
+		if _, err := links.WriteString(tbr.Out, buf.String()); err != nil {
+			WriteFailed(tbr.Out, err, "writing data")
+		}
+	}
+}
+
+// timeLine returns the function which visualizes the step of a row
+func (ro *RenderOptions) timeLine(data MetaData) func(Meta) string {
+	if len(data) == 0 {
+		return func(Meta) string { return "" }
+	}
+	if ro.Waterfall {
+		total := data[len(data)-1].StartDif
+		return func(m Meta) string {
+			return ro.waterfall(m, total)
 		}
+	}
 
-		row := createRow(opt, data[i], timeLine)
+	max, min := data.MaxDuration(), data.MinDuration()
+	return func(m Meta) string {
+		return ro.bar(m.Dur, min, max)
+	}
+}
 
-		table.Append(row)
+// bar visualizes d as up to Divider stars relative to the max duration,
+// min is the smallest step which is the unit of the log scale
+func (ro *RenderOptions) bar(d, min, max time.Duration) string {
+	if d <= 0 || max <= 0 || ro.Divider <= 0 {
+		return ""
 	}
 
-	table.Render()
+	var n int
+	if ro.LogScale {
+		if min <= 0 {
+			min = 1
+		}
+		n = int(math.Ceil(float64(ro.Divider) * math.Log1p(float64(d)/float64(min)) / math.Log1p(float64(max)/float64(min))))
+	} else {
+		step := int(max) / ro.Divider
+		if step < 1 {
+			step = 1
+		}
+		n = (int(d) + step - 1) / step
+	}
+	return strings.Repeat("*", n)
+}
+
+// waterfall visualizes the step of m as stars placed on a line of Divider
+// characters which stands for the total duration of the track
+func (ro *RenderOptions) waterfall(m Meta, total time.Duration) string {
+	if m.Dur <= 0 || total <= 0 || ro.Divider <= 0 {
+		return ""
+	}
+
+	scale := float64(ro.Divider) / float64(total)
+	offset := int(float64(m.StartDif-m.Dur) * scale)
+	if offset < 0 {
+		offset = 0
+	}
+	n := int(math.Ceil(float64(m.Dur) * scale))
+	if n > ro.Divider {
+		n = ro.Divider
+	}
+	if offset+n > ro.Divider {
+		offset = ro.Divider - n
+	}
+	return strings.Repeat(".", offset) + strings.Repeat("*", n)
 }
 
+// JSONRender.Render encodes the checkpoints one by one into a buffered
+// writer, so huge tracks are never held in memory as a single payload
 func (jsr JSONRender) Render(data MetaData, opt *Options) {
-	payload, err := json.MarshalIndent(data, "", "	")
-	if err != nil {
-		log.Printf("err:%s; error marshaling data", err.Error(), )
+	var unit time.Duration
+	if jsr.Options != nil {
+		unit = jsr.Options.NumericUnit
+	}
+
+	w := bufio.NewWriter(jsr.Out)
+	if data == nil {
+		w.WriteString("null")
+	} else if data.hasPhases() {
+		if err := writePhases(w, data, unit); err != nil {
+			WriteFailed(jsr.Out, err, "marshaling data")
+			return
+		}
+	} else {
+		buf := make([]byte, 0, 512)
+
+		w.WriteString("[")
+		for i, m := range data {
+			if i > 0 {
+				w.WriteString(",")
+			}
+			w.WriteString("\n\t")
+
+			var err error
+			if buf, err = appendMeta(buf[:0], m, unit); err != nil {
+				WriteFailed(jsr.Out, err, "marshaling data")
+				return
+			}
+			w.Write(buf)
+		}
+		if len(data) > 0 {
+			w.WriteString("\n")
+		}
+		w.WriteString("]")
 	}
-	_, err = jsr.Out.Write(payload)
-	if err != nil {
-		log.Printf("err:%s; error marshaling data", err.Error(), )
+
+	if err := w.Flush(); err != nil {
+		WriteFailed(jsr.Out, err, "writing data")
 	}
 }
 
 func createHeaders(s []string, opt *Options) []string {
+	if opt.withSeq {
+		s = append(s, "seq")
+	}
+	if opt.withID {
+		s = append(s, "id")
+	}
 	if opt.withName {
 		s = append(s, "func.name")
 	}
@@ -193,6 +664,9 @@ func createHeaders(s []string, opt *Options) []string {
 	if opt.withDuration {
 		s = append(s, "duration")
 	}
+	if opt.withBusy {
+		s = append(s, "busy")
+	}
 	if opt.withErrors {
 		s = append(s, "errors")
 
@@ -200,19 +674,30 @@ func createHeaders(s []string, opt *Options) []string {
 	if opt.withTrack {
 		s = append(s, "track")
 	}
+	if opt.withLink {
+		s = append(s, "link")
+	}
 	return s
 }
 
-func createRow(opt *Options, meta Meta, timeLine string) []string {
-	s := make([]string, 0, 5)
+func createRow(s []string, opt *Options, meta Meta, timeLine string, f columnFormats) []string {
+	if opt.withSeq {
+		s = append(s, f.number(meta.Seq))
+	}
+	if opt.withID {
+		s = append(s, meta.ID)
+	}
 	if opt.withName {
 		s = append(s, meta.Name)
 	}
 	if opt.withSinceStart {
-		s = append(s, meta.StartDif.String())
+		s = append(s, f.sinceStart(meta.StartDif))
 	}
 	if opt.withDuration {
-		s = append(s, meta.Dur.String())
+		s = append(s, f.duration(meta.Dur))
+	}
+	if opt.withBusy {
+		s = append(s, f.busy(meta.Busy()))
 	}
 	if opt.withErrors {
 		if meta.Err == nil {
@@ -224,6 +709,9 @@ func createRow(opt *Options, meta Meta, timeLine string) []string {
 	if opt.withTrack {
 		s = append(s, timeLine)
 	}
+	if opt.withLink {
+		s = append(s, meta.Link())
+	}
 	return s
 }
 
@@ -257,18 +745,81 @@ func (o *Options) WithTrack() *Options {
 	return o
 }
 
+func (o *Options) WithLink() *Options {
+	o.withLink = true
+	return o
+}
+
+func (o *Options) WithBusy() *Options {
+	o.withBusy = true
+	return o
+}
+
+func (o *Options) WithSeq() *Options {
+	o.withSeq = true
+	return o
+}
+
+func (o *Options) WithID() *Options {
+	o.withID = true
+	return o
+}
+
 func (t *Track) SetRenderer(render Renderer) {
 	t.Renderer = render
 }
 
+// SetMaxDepth makes Update() ignore the calls which originate deeper
+// than n frames below the function which created the Track, reducing
+// the noise of instrumented shared helpers. Zero means no limit.
+func (t *Track) SetMaxDepth(n int) {
+	t.maxDepth = n
+}
+
 func (t *Track) Render() {
-	t.Renderer.Render(t.Data, t.options)
+	r := t.Renderer
+	if r == nil {
+		r = TableRender{Out: os.Stdout}
+	}
+	r.Render(t.snapshot(), t.options)
+}
+
+// defaultOptions are the options of the renderers when none are configured
+func defaultOptions() *Options {
+	return &Options{withName: true, withSinceStart: true, withDuration: true, withErrors: true}
+}
+
+// snapshot returns a copy of the checkpoints which is safe to read
+// while checkpoints are still recorded
+func (t *Track) snapshot() MetaData {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.snapshotLocked()
+}
+
+// started reports whether the track has the creation checkpoint
+func (t *Track) started() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.len() > 0
+}
+
+// returns the number of frames on the stack of the calling goroutine
+func stackDepth() int {
+	pc := make([]uintptr, 64)
+	for {
+		n := runtime.Callers(0, pc)
+		if n < len(pc) {
+			return n
+		}
+		pc = make([]uintptr, 2*len(pc))
+	}
 }
 
-//returns the name of the function in which it is called
-func trace(skip int) string {
-	pc := make([]uintptr, skip)
+//returns the name and the call site of the function in which it is called
+func trace(skip int) Meta {
+	pc := make([]uintptr, 1)
 	runtime.Callers(skip, pc)
-	f := runtime.FuncForPC(pc[0])
-	return f.Name()
+	f, _ := runtime.CallersFrames(pc).Next()
+	return Meta{Name: f.Function, File: f.File, Line: f.Line, Pkg: funcPackage(f.Function)}
 }
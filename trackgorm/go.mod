@@ -0,0 +1,16 @@
+module github.com/cat-in-vacuum/tracker/trackgorm
+
+go 1.25.0
+
+require (
+	github.com/cat-in-vacuum/tracker v0.0.0-20261016092840-0dca11a10a1d
+	gorm.io/gorm v1.31.2
+)
+
+require (
+	github.com/jinzhu/inflection v1.0.0 // indirect
+	github.com/jinzhu/now v1.1.5 // indirect
+	github.com/mattn/go-runewidth v0.0.9 // indirect
+	github.com/olekukonko/tablewriter v0.0.5 // indirect
+	golang.org/x/text v0.40.0 // indirect
+)
@@ -0,0 +1,75 @@
+// Package trackgorm records the operations of GORM into the track of
+// the statement context.
+package trackgorm
+
+import (
+	"errors"
+
+	"github.com/cat-in-vacuum/tracker"
+	"gorm.io/gorm"
+)
+
+// doneKey keeps the func recording the operation in the statement
+const doneKey = "tracker:done"
+
+// Plugin is a gorm.Plugin recording every create, query, update,
+// delete, row and raw operation as a checkpoint "gorm op" with the
+// table attribute, see tracker.Hook, a record not found is not an
+// error. Pass the track with the context:
+//
+//	db.Use(trackgorm.Plugin{})
+//	db.WithContext(tracker.NewContext(ctx, t)).Find(&users)
+type Plugin struct{}
+
+var _ gorm.Plugin = Plugin{}
+
+func (Plugin) Name() string {
+	return "tracker"
+}
+
+func (Plugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	ops := []struct {
+		name          string
+		before, after func(string, func(*gorm.DB)) error
+	}{
+		{"create", cb.Create().Before("gorm:create").Register, cb.Create().After("gorm:create").Register},
+		{"query", cb.Query().Before("gorm:query").Register, cb.Query().After("gorm:query").Register},
+		{"update", cb.Update().Before("gorm:update").Register, cb.Update().After("gorm:update").Register},
+		{"delete", cb.Delete().Before("gorm:delete").Register, cb.Delete().After("gorm:delete").Register},
+		{"row", cb.Row().Before("gorm:row").Register, cb.Row().After("gorm:row").Register},
+		{"raw", cb.Raw().Before("gorm:raw").Register, cb.Raw().After("gorm:raw").Register},
+	}
+
+	for _, op := range ops {
+		if err := op.before("tracker:before_"+op.name, before(op.name)); err != nil {
+			return err
+		}
+		if err := op.after("tracker:after_"+op.name, after); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func before(op string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Statement == nil || db.Statement.Context == nil {
+			return
+		}
+		done := tracker.Hook(db.Statement.Context, "gorm", op, "table", db.Statement.Table)
+		db.InstanceSet(doneKey, done)
+	}
+}
+
+func after(db *gorm.DB) {
+	if v, ok := db.InstanceGet(doneKey); ok {
+		if done, ok := v.(func(error)); ok {
+			err := db.Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				err = nil
+			}
+			done(err)
+		}
+	}
+}
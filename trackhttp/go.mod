@@ -0,0 +1,12 @@
+module github.com/cat-in-vacuum/tracker/trackhttp
+
+go 1.25.0
+
+require (
+	github.com/cat-in-vacuum/tracker v0.0.0-20261016092723-aaa36eb20de6
+	github.com/go-chi/chi/v5 v5.3.1
+	github.com/gorilla/mux v1.8.1
+	github.com/olekukonko/tablewriter v0.0.5
+)
+
+require github.com/mattn/go-runewidth v0.0.9 // indirect
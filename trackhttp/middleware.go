@@ -0,0 +1,154 @@
+// Package trackhttp tracks the requests of a HTTP server: every request
+// gets its own tracker.Track carried by the request context, and the
+// latencies can be aggregated by route.
+package trackhttp
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cat-in-vacuum/tracker"
+)
+
+// Middleware creates a track for every request by Factory and records
+// the request as a checkpoint named "METHOD route" with the attributes
+// status, request_bytes and response_bytes. The route is the pattern
+// matched by Router, the requests matched by no route are named
+// "METHOD unmatched". A panicking handler is recorded with the status
+// 500 and the panic is re-raised. The handlers get the track with
+// tracker.FromContext.
+type Middleware struct {
+	// Factory creates the tracks, tracker.NewFactory(3) if nil
+	Factory *tracker.Factory
+	// Router finds the route patterns, ServeMux if nil
+	Router Router
+	// Routes aggregates the latencies by route if set
+	Routes *Routes
+	// Done is called with the finished track of every request if set,
+	// e.g. to render it or to add it into a tracker.Aggregator
+	Done func(r *http.Request, t *tracker.Track)
+}
+
+// Wrap returns next tracked by the middleware
+func (m Middleware) Wrap(next http.Handler) http.Handler {
+	f := m.Factory
+	if f == nil {
+		f = tracker.NewFactory(3)
+	}
+	router := m.Router
+	if router == nil {
+		router = ServeMux{}
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, t := f.NewTrack(r.Context())
+		r = router.Prepare(r.WithContext(ctx))
+
+		var body *countingBody
+		if r.Body != nil && r.Body != http.NoBody {
+			body = &countingBody{ReadCloser: r.Body}
+			r.Body = body
+		}
+		rw := &responseWriter{ResponseWriter: w}
+
+		start := time.Now()
+		s := t.Step(r.Method + " " + r.URL.Path)
+		defer func() {
+			dur := time.Since(start)
+			status := rw.statusCode()
+			// the panic goes on after the request is recorded
+			p := recover()
+			if p != nil {
+				status = http.StatusInternalServerError
+				s.Err(fmt.Errorf("panic: %v", p))
+			}
+
+			route := router.Route(r)
+			if route == "" {
+				route = "unmatched"
+			}
+			s.Name(r.Method + " " + route)
+			s.Attr("status", strconv.Itoa(status))
+			s.Attr("request_bytes", strconv.FormatInt(requestSize(r, body), 10))
+			s.Attr("response_bytes", strconv.FormatInt(rw.size, 10))
+			s.Done()
+
+			if m.Routes != nil {
+				m.Routes.Add(r.Method+" "+route, dur)
+			}
+			if m.Done != nil {
+				m.Done(r, t)
+			}
+			if p != nil {
+				panic(p)
+			}
+		}()
+		next.ServeHTTP(rw, r)
+	})
+}
+
+// requestSize returns the bytes of the body read by the handler,
+// or the declared length if the handler read less
+func requestSize(r *http.Request, body *countingBody) int64 {
+	var n int64
+	if body != nil {
+		n = body.n
+	}
+	return max(n, r.ContentLength)
+}
+
+type countingBody struct {
+	io.ReadCloser
+	n int64
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	return n, err
+}
+
+// responseWriter records the status and the size of the response
+type responseWriter struct {
+	http.ResponseWriter
+	status int
+	size   int64
+}
+
+func (w *responseWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.size += int64(n)
+	return n, err
+}
+
+// Unwrap lets http.ResponseController reach the flusher, hijacker...
+// of the wrapped writer
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *responseWriter) statusCode() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
@@ -0,0 +1,85 @@
+package trackhttp
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/mux"
+)
+
+// Router finds the route pattern the router matched the request with,
+// so the checkpoints and the Routes aggregate by endpoint with bounded
+// cardinality instead of by raw URL
+type Router interface {
+	// Prepare returns the request to serve, it lets the routers which
+	// keep the match in the request context share it with the middleware
+	Prepare(r *http.Request) *http.Request
+	// Route returns the pattern of the served request,
+	// or an empty string if no route matched
+	Route(r *http.Request) string
+}
+
+// ServeMux finds the patterns of http.ServeMux (Go 1.22 patterns)
+// when the middleware wraps the mux
+type ServeMux struct{}
+
+func (ServeMux) Prepare(r *http.Request) *http.Request {
+	return r
+}
+
+func (ServeMux) Route(r *http.Request) string {
+	return stripMethod(r.Pattern)
+}
+
+// stripMethod drops the method of a pattern like "GET /items/{id}",
+// the method is a part of the checkpoint name anyway
+func stripMethod(pattern string) string {
+	if i := strings.IndexByte(pattern, ' '); i >= 0 && !strings.HasPrefix(pattern, "/") {
+		return strings.TrimLeft(pattern[i+1:], " \t")
+	}
+	return pattern
+}
+
+// Chi finds the patterns of a chi router, the middleware either wraps
+// the router or is installed with its Use
+type Chi struct{}
+
+// Prepare adds the chi routing context to the request, chi fills the
+// given context instead of the one of a new request then
+func (Chi) Prepare(r *http.Request) *http.Request {
+	if chi.RouteContext(r.Context()) != nil {
+		return r
+	}
+	ctx := context.WithValue(r.Context(), chi.RouteCtxKey, chi.NewRouteContext())
+	return r.WithContext(ctx)
+}
+
+func (Chi) Route(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		return rctx.RoutePattern()
+	}
+	return ""
+}
+
+// Gorilla finds the path templates of a gorilla/mux router, the router
+// keeps the match in a new request, so the middleware must be installed
+// with its Use
+type Gorilla struct{}
+
+func (Gorilla) Prepare(r *http.Request) *http.Request {
+	return r
+}
+
+func (Gorilla) Route(r *http.Request) string {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return ""
+	}
+	tpl, err := route.GetPathTemplate()
+	if err != nil {
+		return ""
+	}
+	return tpl
+}
@@ -0,0 +1,88 @@
+package trackhttp
+
+import (
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cat-in-vacuum/tracker"
+	"github.com/olekukonko/tablewriter"
+)
+
+// Routes aggregates the request latencies by route into histograms.
+// It is safe for concurrent use.
+type Routes struct {
+	mu      sync.Mutex
+	byRoute map[string]*tracker.Histogram
+}
+
+func NewRoutes() *Routes {
+	return &Routes{byRoute: make(map[string]*tracker.Histogram)}
+}
+
+// Add records the latency of a request of the route
+func (rs *Routes) Add(route string, d time.Duration) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	h, ok := rs.byRoute[route]
+	if !ok {
+		h = &tracker.Histogram{Name: route}
+		rs.byRoute[route] = h
+	}
+	h.Record(d)
+}
+
+// RouteStat is the latency summary of a route
+type RouteStat struct {
+	Route string        `json:"route"`
+	Count uint64        `json:"count"`
+	P50   time.Duration `json:"p50"`
+	P90   time.Duration `json:"p90"`
+	P99   time.Duration `json:"p99"`
+	Max   time.Duration `json:"max"`
+}
+
+// Stats returns the latency percentiles of the routes ordered by route
+func (rs *Routes) Stats() []RouteStat {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	stats := make([]RouteStat, 0, len(rs.byRoute))
+	for route, h := range rs.byRoute {
+		stats = append(stats, RouteStat{
+			Route: route,
+			Count: h.Count(),
+			P50:   h.Quantile(0.5),
+			P90:   h.Quantile(0.9),
+			P99:   h.Quantile(0.99),
+			Max:   h.Max(),
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Route < stats[j].Route })
+	return stats
+}
+
+// Render writes the stats of the routes as a table
+func (rs *Routes) Render(out io.Writer) {
+	cw := tracker.WritePolicy{OnFailure: tracker.ReturnFailure}.Wrap(out)
+	table := tablewriter.NewWriter(cw)
+	table.SetHeader([]string{"route", "count", "p50", "p90", "p99", "max"})
+
+	for _, s := range rs.Stats() {
+		table.Append([]string{
+			s.Route,
+			strconv.FormatUint(s.Count, 10),
+			s.P50.String(),
+			s.P90.String(),
+			s.P99.String(),
+			s.Max.String(),
+		})
+	}
+
+	table.Render()
+	if err := cw.Err(); err != nil {
+		tracker.WriteFailed(out, err, "writing routes")
+	}
+}
@@ -0,0 +1,54 @@
+// Package trackotel mirrors OpenTelemetry spans into a tracker.Track,
+// so services already instrumented with OTel can reuse the table and
+// timeline renderers of the tracker locally.
+package trackotel
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cat-in-vacuum/tracker"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// SpanProcessor is a sdktrace.SpanProcessor which records every
+// finished span into the Track as a checkpoint: the checkpoint is
+// placed at the end of the span and its duration is the span duration.
+//
+//	tp := sdktrace.NewTracerProvider(
+//		sdktrace.WithSpanProcessor(trackotel.NewSpanProcessor(t)),
+//	)
+type SpanProcessor struct {
+	track *tracker.Track
+}
+
+var _ sdktrace.SpanProcessor = (*SpanProcessor)(nil)
+
+func NewSpanProcessor(t *tracker.Track) *SpanProcessor {
+	return &SpanProcessor{track: t}
+}
+
+func (p *SpanProcessor) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+func (p *SpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	var err error
+	if status := s.Status(); status.Code == codes.Error {
+		err = errors.New(status.Description)
+	}
+	// Insert, not Record: the caller here is the SDK, not the span
+	p.track.Insert(tracker.Meta{
+		Name:  s.Name(),
+		Start: s.EndTime(),
+		Dur:   s.EndTime().Sub(s.StartTime()),
+		Err:   err,
+	})
+}
+
+func (p *SpanProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+func (p *SpanProcessor) ForceFlush(context.Context) error {
+	return nil
+}
@@ -0,0 +1,27 @@
+package trackotel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cat-in-vacuum/tracker"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestSpanProcessorRecordsNoLocation(t *testing.T) {
+	tr := tracker.New(0)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(NewSpanProcessor(tr)))
+	defer tp.Shutdown(context.Background())
+
+	_, span := tp.Tracer("test").Start(context.Background(), "query")
+	span.End()
+
+	data := tr.Snapshot()
+	if len(data) != 2 {
+		t.Fatalf("got %d checkpoints, want the creation and the span", len(data))
+	}
+	m := data[1]
+	if m.Name != "query" || m.File != "" || m.Line != 0 {
+		t.Errorf("got %s at %s:%d, want the span without a location", m.Name, m.File, m.Line)
+	}
+}
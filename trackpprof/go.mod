@@ -0,0 +1,14 @@
+module github.com/cat-in-vacuum/tracker/trackpprof
+
+go 1.25.0
+
+require (
+	github.com/cat-in-vacuum/tracker v0.0.0-20261016092701-f3f65ff2d5ea
+	github.com/google/pprof v0.0.0-20210407192527-94a9f03dee38
+	golang.org/x/exp v0.0.0-20260611194520-c48552f49976
+)
+
+require (
+	github.com/mattn/go-runewidth v0.0.9 // indirect
+	github.com/olekukonko/tablewriter v0.0.5 // indirect
+)
@@ -0,0 +1,158 @@
+package trackpprof
+
+import (
+	"errors"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/cat-in-vacuum/tracker"
+	"github.com/google/pprof/profile"
+	"golang.org/x/exp/trace"
+)
+
+// funcStat is the time attributed to a function
+type funcStat struct {
+	name string
+	file string
+	line int
+	dur  time.Duration
+}
+
+// FromProfile converts the top functions of a CPU profile by flat time
+// into checkpoints, so profiled data can be rendered as tracked data:
+// the checkpoints follow one another from the start of the profile,
+// longest first, each as long as the time of its function.
+func FromProfile(r io.Reader, top int) (tracker.MetaData, error) {
+	p, err := profile.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	value := -1
+	for i, st := range p.SampleType {
+		if st.Unit == "nanoseconds" {
+			value = i
+			break
+		}
+	}
+	if value < 0 {
+		return nil, errors.New("the profile has no sample values in nanoseconds")
+	}
+
+	stats := make(map[string]*funcStat)
+	for _, s := range p.Sample {
+		if len(s.Location) == 0 || len(s.Location[0].Line) == 0 {
+			continue
+		}
+		// the first line of the leaf location is the innermost function
+		line := s.Location[0].Line[0]
+		if line.Function == nil {
+			continue
+		}
+		st := stat(stats, line.Function.Name, line.Function.Filename, int(line.Line))
+		st.dur += time.Duration(s.Value[value])
+	}
+
+	return topData("cpu profile", time.Unix(0, p.TimeNanos), stats, top), nil
+}
+
+// FromTrace converts the top functions of a runtime trace by running
+// time into checkpoints the same way as FromProfile. A running slice of
+// a goroutine is attributed to the function it stopped running in.
+func FromTrace(r io.Reader, top int) (tracker.MetaData, error) {
+	tr, err := trace.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var start time.Time
+	running := make(map[trace.GoID]trace.Time)
+	stats := make(map[string]*funcStat)
+	for {
+		e, err := tr.ReadEvent()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch e.Kind() {
+		case trace.EventSync:
+			if snap := e.Sync().ClockSnapshot; snap != nil && start.IsZero() {
+				start = snap.Wall
+			}
+		case trace.EventStateTransition:
+			st := e.StateTransition()
+			if st.Resource.Kind != trace.ResourceGoroutine {
+				continue
+			}
+			id := st.Resource.Goroutine()
+			from, to := st.Goroutine()
+			if to == trace.GoRunning {
+				running[id] = e.Time()
+			}
+			if from == trace.GoRunning {
+				began, ok := running[id]
+				if !ok {
+					continue
+				}
+				delete(running, id)
+
+				name, file, line := "(unknown)", "", 0
+				for f := range e.Stack().Frames() {
+					name, file, line = f.Func, f.File, int(f.Line)
+					break
+				}
+				stat(stats, name, file, line).dur += e.Time().Sub(began)
+			}
+		}
+	}
+
+	return topData("runtime trace", start, stats, top), nil
+}
+
+func stat(stats map[string]*funcStat, name, file string, line int) *funcStat {
+	st, ok := stats[name]
+	if !ok {
+		st = &funcStat{name: name, file: file, line: line}
+		stats[name] = st
+	}
+	return st
+}
+
+// topData lays the top functions out one after another from start,
+// all of them if top is not positive
+func topData(source string, start time.Time, stats map[string]*funcStat, top int) tracker.MetaData {
+	sorted := make([]*funcStat, 0, len(stats))
+	for _, st := range stats {
+		sorted = append(sorted, st)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].dur != sorted[j].dur {
+			return sorted[i].dur > sorted[j].dur
+		}
+		return sorted[i].name < sorted[j].name
+	})
+	if top > 0 && len(sorted) > top {
+		sorted = sorted[:top]
+	}
+
+	data := make(tracker.MetaData, 0, len(sorted)+1)
+	data = append(data, tracker.Meta{Name: source, Start: start})
+	var offset time.Duration
+	for i, st := range sorted {
+		offset += st.dur
+		data = append(data, tracker.Meta{
+			Name:     st.name,
+			Start:    start.Add(offset),
+			Dur:      st.dur,
+			StartDif: offset,
+			File:     st.file,
+			Line:     st.line,
+			Seq:      uint64(i + 1),
+		})
+	}
+	return data
+}
@@ -0,0 +1,76 @@
+// Package trackpprof exports track data as a pprof profile, so
+// `go tool pprof` and its web UI can be used to explore it.
+package trackpprof
+
+import (
+	"io"
+
+	"github.com/cat-in-vacuum/tracker"
+	"github.com/google/pprof/profile"
+)
+
+// Render writes a gzipped pprof profile with one sample per function
+// name: the number of checkpoints and their total duration.
+//
+//	go tool pprof -http=:8080 track.pb.gz
+type Render struct {
+	Out io.Writer
+}
+
+var _ tracker.Renderer = Render{}
+
+func (r Render) Render(data tracker.MetaData, _ *tracker.Options) {
+	if err := Profile(data).Write(r.Out); err != nil {
+		tracker.WriteFailed(r.Out, err, "writing profile")
+	}
+}
+
+// Profile builds a pprof profile from data, samples are keyed by
+// checkpoint name and valued by count and summed duration. The creation
+// checkpoint of the track is not a step and makes no sample.
+func Profile(data tracker.MetaData) *profile.Profile {
+	p := &profile.Profile{
+		SampleType: []*profile.ValueType{
+			{Type: "checkpoints", Unit: "count"},
+			{Type: "duration", Unit: "nanoseconds"},
+		},
+		DefaultSampleType: "duration",
+	}
+	if len(data) > 0 {
+		p.TimeNanos = data[0].Start.UnixNano()
+		p.DurationNanos = int64(data[len(data)-1].StartDif)
+	}
+
+	// the first elem is the creation of the track, it is not a step
+	var steps tracker.MetaData
+	if len(data) > 1 {
+		steps = data[1:]
+	}
+
+	samples := make(map[string]*profile.Sample)
+	for _, m := range steps {
+		s, ok := samples[m.Name]
+		if !ok {
+			fn := &profile.Function{
+				ID:         uint64(len(p.Function) + 1),
+				Name:       m.Name,
+				SystemName: m.Name,
+			}
+			loc := &profile.Location{
+				ID:   uint64(len(p.Location) + 1),
+				Line: []profile.Line{{Function: fn}},
+			}
+			s = &profile.Sample{
+				Location: []*profile.Location{loc},
+				Value:    make([]int64, 2),
+			}
+			p.Function = append(p.Function, fn)
+			p.Location = append(p.Location, loc)
+			p.Sample = append(p.Sample, s)
+			samples[m.Name] = s
+		}
+		s.Value[0]++
+		s.Value[1] += int64(m.Dur)
+	}
+	return p
+}
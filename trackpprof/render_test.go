@@ -0,0 +1,36 @@
+package trackpprof
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cat-in-vacuum/tracker"
+)
+
+func TestProfileSkipsCreation(t *testing.T) {
+	data := tracker.MetaData{
+		{Name: "main"},
+		{Name: "load", Dur: 2 * time.Millisecond, StartDif: 2 * time.Millisecond},
+		{Name: "load", Dur: 3 * time.Millisecond, StartDif: 5 * time.Millisecond},
+		{Name: "save", Dur: time.Millisecond, StartDif: 6 * time.Millisecond},
+	}
+
+	want := map[string][2]int64{
+		"load": {2, int64(5 * time.Millisecond)},
+		"save": {1, int64(time.Millisecond)},
+	}
+	p := Profile(data)
+	if len(p.Sample) != len(want) {
+		t.Fatalf("got %d samples, want %d", len(p.Sample), len(want))
+	}
+	for _, s := range p.Sample {
+		name := s.Location[0].Line[0].Function.Name
+		if w, ok := want[name]; !ok || s.Value[0] != w[0] || s.Value[1] != w[1] {
+			t.Errorf("%s: got values %v, want %v", name, s.Value, w)
+		}
+	}
+
+	if n := len(Profile(data[:1]).Sample); n != 0 {
+		t.Errorf("got %d samples of a track without steps", n)
+	}
+}
@@ -0,0 +1,15 @@
+module github.com/cat-in-vacuum/tracker/trackredis
+
+go 1.25.0
+
+require (
+	github.com/cat-in-vacuum/tracker v0.0.0-20261016092821-e2e7a0def89f
+	github.com/redis/go-redis/v9 v9.9.0
+)
+
+require (
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	github.com/mattn/go-runewidth v0.0.9 // indirect
+	github.com/olekukonko/tablewriter v0.0.5 // indirect
+)
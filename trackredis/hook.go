@@ -0,0 +1,54 @@
+// Package trackredis records the commands of go-redis clients into the
+// track of the command context.
+package trackredis
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/cat-in-vacuum/tracker"
+	"github.com/redis/go-redis/v9"
+)
+
+// Hook is a redis.Hook recording every command, pipeline and dial as a
+// checkpoint with tracker.Hook, a missing key (redis.Nil) is not an error
+//
+//	rdb.AddHook(trackredis.Hook{})
+type Hook struct{}
+
+var _ redis.Hook = Hook{}
+
+func (Hook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		done := tracker.Hook(ctx, "redis", "dial")
+		conn, err := next(ctx, network, addr)
+		done(err)
+		return conn, err
+	}
+}
+
+func (Hook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		done := tracker.Hook(ctx, "redis", cmd.Name())
+		err := next(ctx, cmd)
+		done(cmdErr(err))
+		return err
+	}
+}
+
+func (Hook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		done := tracker.Hook(ctx, "redis", "pipeline")
+		err := next(ctx, cmds)
+		done(cmdErr(err))
+		return err
+	}
+}
+
+func cmdErr(err error) error {
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,137 @@
+package tracker
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// durFormat formats the durations of a column
+type durFormat func(time.Duration) string
+
+// columnFormats holds the formats of the duration columns of a table
+// and the number format of the counts, nil if they are plain
+type columnFormats struct {
+	sinceStart, duration, busy durFormat
+	numbers                    *NumberFormat
+}
+
+// sameFormats returns the formats with f for every duration column
+func sameFormats(f durFormat) columnFormats {
+	return columnFormats{sinceStart: f, duration: f, busy: f}
+}
+
+// formats returns the formats of the duration columns of data,
+// time.Duration.String unless AutoUnit or Humanize is set
+func (ro *RenderOptions) formats(data MetaData) columnFormats {
+	if ro.Humanize != nil {
+		f := sameFormats(ro.Humanize.Humanize)
+		f.numbers = ro.Numbers
+		return f
+	}
+	if !ro.AutoUnit {
+		f := sameFormats(time.Duration.String)
+		f.numbers = ro.Numbers
+		return f
+	}
+
+	var since, dur, busy time.Duration
+	for _, m := range data {
+		since = maxDur(since, m.StartDif)
+		dur = maxDur(dur, m.Dur)
+		busy = maxDur(busy, m.Busy())
+	}
+	f := columnFormats{unitFormat(since), unitFormat(dur), unitFormat(busy), ro.Numbers}
+	if ro.Numbers != nil {
+		f.sinceStart = localized(f.sinceStart, ro.Numbers)
+		f.duration = localized(f.duration, ro.Numbers)
+		f.busy = localized(f.busy, ro.Numbers)
+	}
+	return f
+}
+
+// localized sets the decimal separator of the durations formatted by f
+func localized(f durFormat, nf *NumberFormat) durFormat {
+	return func(d time.Duration) string {
+		return nf.localize(f(d))
+	}
+}
+
+// number formats a count of a row
+func (f columnFormats) number(n uint64) string {
+	if f.numbers == nil {
+		return strconv.FormatUint(n, 10)
+	}
+	return f.numbers.Int(int64(n))
+}
+
+func maxDur(a, b time.Duration) time.Duration {
+	if b > a {
+		return b
+	}
+	return a
+}
+
+// unitFormat returns the format for a column whose largest duration is max
+func unitFormat(max time.Duration) durFormat {
+	unit := func(u time.Duration, suffix string) durFormat {
+		return func(d time.Duration) string {
+			return fmt.Sprintf("%.3f%s", float64(d)/float64(u), suffix)
+		}
+	}
+
+	switch {
+	case max < time.Microsecond:
+		return func(d time.Duration) string {
+			return fmt.Sprintf("%dns", int64(d))
+		}
+	case max < time.Millisecond:
+		return unit(time.Microsecond, "µs")
+	case max < time.Second:
+		return unit(time.Millisecond, "ms")
+	case max < time.Minute:
+		return unit(time.Second, "s")
+	case max < time.Hour:
+		return func(d time.Duration) string {
+			d = d.Round(time.Millisecond)
+			return fmt.Sprintf("%d:%06.3f", d/time.Minute, (d % time.Minute).Seconds())
+		}
+	}
+	return func(d time.Duration) string {
+		d = d.Round(time.Second)
+		return fmt.Sprintf("%d:%02d:%02d", d/time.Hour, d%time.Hour/time.Minute, d%time.Minute/time.Second)
+	}
+}
+
+// unitName returns the name of the unit of NumericUnit noted in
+// the headers, units other than ns, us, ms, s, m and h are taken as ns
+func unitName(u time.Duration) string {
+	switch u {
+	case time.Microsecond:
+		return "us"
+	case time.Millisecond:
+		return "ms"
+	case time.Second:
+		return "s"
+	case time.Minute:
+		return "m"
+	case time.Hour:
+		return "h"
+	}
+	return "ns"
+}
+
+// appendNumeric appends d as a plain number of the unit u, see unitName
+func appendNumeric(b []byte, d, u time.Duration) []byte {
+	if unitName(u) == "ns" {
+		return strconv.AppendInt(b, int64(d), 10)
+	}
+	return strconv.AppendFloat(b, float64(d)/float64(u), 'f', -1, 64)
+}
+
+// numericFormat formats durations as plain numbers of the unit u
+func numericFormat(u time.Duration) durFormat {
+	return func(d time.Duration) string {
+		return string(appendNumeric(nil, d, u))
+	}
+}
@@ -0,0 +1,153 @@
+package tracker
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// Columns selects the columns rendered by TableRender
+type Columns uint
+
+const (
+	ColName Columns = 1 << iota
+	ColSinceStart
+	ColDuration
+	ColErrors
+	ColTrack
+	ColLink
+	ColSeq
+
+	// ColAll renders every column
+	ColAll = ColName | ColSinceStart | ColDuration | ColErrors | ColTrack | ColLink | ColSeq
+)
+
+// TableRender renders the checkpoints as a text table, the track column
+// visualizes the durations with up to Divider stars
+type TableRender struct {
+	Out     io.Writer
+	Divider int
+}
+
+func (tbr TableRender) Render(data MetaData, cols Columns) error {
+	if cols == 0 {
+		cols = ColName | ColSinceStart | ColDuration | ColErrors
+	}
+
+	var headers []string
+	for _, c := range []struct {
+		col  Columns
+		name string
+	}{
+		{ColSeq, "seq"},
+		{ColName, "func.name"},
+		{ColSinceStart, "since.start"},
+		{ColDuration, "duration"},
+		{ColErrors, "errors"},
+		{ColTrack, "track"},
+		{ColLink, "link"},
+	} {
+		if cols&c.col != 0 {
+			headers = append(headers, c.name)
+		}
+	}
+
+	out := &errWriter{w: tbr.Out}
+	table := tablewriter.NewWriter(out)
+	table.SetHeader(headers)
+
+	max := data.MaxDuration()
+	for _, m := range data {
+		row := make([]string, 0, len(headers))
+		if cols&ColSeq != 0 {
+			row = append(row, strconv.FormatUint(m.Seq, 10))
+		}
+		if cols&ColName != 0 {
+			row = append(row, m.Name)
+		}
+		if cols&ColSinceStart != 0 {
+			row = append(row, m.StartDif.String())
+		}
+		if cols&ColDuration != 0 {
+			row = append(row, m.Dur.String())
+		}
+		if cols&ColErrors != 0 {
+			var s string
+			if m.Err != nil {
+				s = m.Err.Error()
+			}
+			row = append(row, s)
+		}
+		if cols&ColTrack != 0 {
+			row = append(row, tbr.bar(m.Dur, max))
+		}
+		if cols&ColLink != 0 {
+			var s string
+			if m.File != "" {
+				s = m.File + ":" + strconv.Itoa(m.Line)
+			}
+			row = append(row, s)
+		}
+		table.Append(row)
+	}
+
+	table.Render()
+	return out.err
+}
+
+// errWriter keeps the first error of writing into w and drops the rest
+// of the output, tablewriter does not return the errors
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) Write(p []byte) (int, error) {
+	if ew.err != nil {
+		return 0, ew.err
+	}
+	n, err := ew.w.Write(p)
+	ew.err = err
+	return n, err
+}
+
+func (tbr TableRender) bar(d, max time.Duration) string {
+	if d <= 0 || max <= 0 || tbr.Divider <= 0 {
+		return ""
+	}
+	return strings.Repeat("*", int((int64(d)*int64(tbr.Divider)+int64(max)-1)/int64(max)))
+}
+
+// JSONRender renders the checkpoints as an indented JSON array,
+// errors are encoded as their messages
+type JSONRender struct {
+	Out io.Writer
+}
+
+type jsonMeta struct {
+	Meta
+	Error string `json:"error,omitempty"`
+}
+
+func (jsr JSONRender) Render(data MetaData, _ Columns) error {
+	out := make([]jsonMeta, len(data))
+	for i, m := range data {
+		out[i].Meta = m
+		if m.Err != nil {
+			out[i].Error = m.Err.Error()
+		}
+	}
+
+	w := bufio.NewWriter(jsr.Out)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	if err := enc.Encode(out); err != nil {
+		return err
+	}
+	return w.Flush()
+}
@@ -0,0 +1,224 @@
+// Package tracker is the second major version of the tracker: it records
+// checkpoints of the steps of a function (name, call site, duration since
+// the previous checkpoint and since the start) and renders them.
+//
+// Differences from v1:
+//   - the track is configured with functional options given to New or Start
+//   - the message format of live logging belongs to the track, not to the package
+//   - the checkpoints are unexported, they are read through Len, Get, Snapshot and All
+//   - renderers return errors instead of logging them
+//   - tracks travel in a context.Context (Start, FromContext)
+//   - the zero value Track is ready to use
+//
+// v1 keeps working, both versions can be used side by side while migrating.
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"iter"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// DefaultMessageFormat is the format of the live log lines,
+// it gets the name, the time since start and the duration of a checkpoint
+const DefaultMessageFormat = "function:[%s]|sinceStart:[%s]|duration:[%s]|\n"
+
+// Meta is a single checkpoint
+type Meta struct {
+	Name     string        `json:"name"`
+	Start    time.Time     `json:"start"`
+	Dur      time.Duration `json:"dur"`
+	StartDif time.Duration `json:"start_dif"`
+	Err      error         `json:"-"`
+	File     string        `json:"file,omitempty"`
+	Line     int           `json:"line,omitempty"`
+	Seq      uint64        `json:"seq"`
+}
+
+// MetaData is a set of checkpoints, the first one is the start of the track
+type MetaData []Meta
+
+// Renderer renders the checkpoints into its output
+type Renderer interface {
+	Render(data MetaData, cols Columns) error
+}
+
+// Track records the checkpoints, it is safe for concurrent use.
+// The zero value is a track started by the first Update.
+type Track struct {
+	mu         sync.Mutex
+	data       MetaData
+	seq        uint64
+	callerSkip int
+	format     string
+	log        io.Writer
+	renderer   Renderer
+	columns    Columns
+}
+
+// Option configures a Track
+type Option func(*Track)
+
+// WithCallerSkip makes the track name checkpoints after the function n frames
+// above the caller of New and Update, for helpers which wrap them
+func WithCallerSkip(n int) Option {
+	return func(t *Track) { t.callerSkip = n }
+}
+
+// WithRenderer sets the renderer used by Track.Render
+func WithRenderer(r Renderer) Option {
+	return func(t *Track) { t.renderer = r }
+}
+
+// WithColumns sets the columns rendered by Track.Render
+func WithColumns(c Columns) Option {
+	return func(t *Track) { t.columns = c }
+}
+
+// WithLog writes a line into w for every checkpoint as it is recorded
+func WithLog(w io.Writer) Option {
+	return func(t *Track) { t.log = w }
+}
+
+// WithMessageFormat sets the format of the lines written by WithLog,
+// see DefaultMessageFormat
+func WithMessageFormat(format string) Option {
+	return func(t *Track) { t.format = format }
+}
+
+// New creates a track and records its start checkpoint
+func New(opts ...Option) *Track {
+	t := configure(opts)
+	t.start(trace(3 + t.callerSkip))
+	return t
+}
+
+type ctxKey struct{}
+
+// Start creates a track with New and returns it
+// together with a copy of ctx which carries it
+func Start(ctx context.Context, opts ...Option) (context.Context, *Track) {
+	t := configure(opts)
+	t.start(trace(3 + t.callerSkip))
+	return context.WithValue(ctx, ctxKey{}, t), t
+}
+
+func configure(opts []Option) *Track {
+	t := &Track{}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+func (t *Track) start(meta Meta) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.add(meta, nil)
+}
+
+// FromContext returns the track carried by ctx, or nil
+func FromContext(ctx context.Context) *Track {
+	t, _ := ctx.Value(ctxKey{}).(*Track)
+	return t
+}
+
+// Update records a checkpoint named after the calling function,
+// its duration is the time since the previous checkpoint.
+// The first Update of a zero value Track is its start.
+func (t *Track) Update(err error) {
+	meta := trace(3 + t.callerSkip)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.add(meta, err)
+}
+
+// add records the checkpoint, t.mu must be held
+func (t *Track) add(meta Meta, err error) {
+	meta.Start = time.Now()
+	meta.Err = err
+	meta.Seq = t.seq
+	t.seq++
+	if len(t.data) > 0 {
+		meta.Dur = meta.Start.Sub(t.data[len(t.data)-1].Start)
+		meta.StartDif = meta.Start.Sub(t.data[0].Start)
+	}
+	t.data = append(t.data, meta)
+
+	if t.log != nil {
+		format := t.format
+		if format == "" {
+			format = DefaultMessageFormat
+		}
+		fmt.Fprintf(t.log, format, meta.Name, meta.StartDif, meta.Dur)
+	}
+}
+
+// Render renders the checkpoints with the renderer of the track
+func (t *Track) Render() error {
+	if t.renderer == nil {
+		return fmt.Errorf("tracker: no renderer, use WithRenderer")
+	}
+	return t.renderer.Render(t.Snapshot(), t.columns)
+}
+
+// Len returns the number of the checkpoints
+func (t *Track) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.data)
+}
+
+// Get returns a copy of the i-th checkpoint, false if there is no such one
+func (t *Track) Get(i int) (Meta, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if i < 0 || i >= len(t.data) {
+		return Meta{}, false
+	}
+	return t.data[i], true
+}
+
+// Snapshot returns a copy of the checkpoints
+func (t *Track) Snapshot() MetaData {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append(MetaData(nil), t.data...)
+}
+
+// All returns an iterator over the checkpoints, the track is locked
+// only while a single checkpoint is read
+func (t *Track) All() iter.Seq2[int, Meta] {
+	return func(yield func(int, Meta) bool) {
+		for i := 0; ; i++ {
+			m, ok := t.Get(i)
+			if !ok || !yield(i, m) {
+				return
+			}
+		}
+	}
+}
+
+// MaxDuration returns the longest duration of the checkpoints
+func (m MetaData) MaxDuration() time.Duration {
+	var max time.Duration
+	for _, e := range m {
+		if e.Dur > max {
+			max = e.Dur
+		}
+	}
+	return max
+}
+
+// returns the name and the call site of the function skip frames up
+func trace(skip int) Meta {
+	pc := make([]uintptr, 1)
+	runtime.Callers(skip, pc)
+	f, _ := runtime.CallersFrames(pc).Next()
+	return Meta{Name: f.Function, File: f.File, Line: f.Line}
+}
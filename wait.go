@@ -0,0 +1,55 @@
+package tracker
+
+import (
+	"context"
+	"time"
+)
+
+// Wait runs fn and counts its duration as blocking time of the next
+// checkpoint, so the reports can tell waiting from computing (see Meta.Busy)
+func (t *Track) Wait(fn func()) {
+	start := t.now()
+	fn()
+	t.block(t.now().Sub(start))
+}
+
+// WaitContext blocks until ctx is done and counts the waiting
+// as blocking time of the next checkpoint, it returns ctx.Err()
+func (t *Track) WaitContext(ctx context.Context) error {
+	start := t.now()
+	<-ctx.Done()
+	t.block(t.now().Sub(start))
+	return ctx.Err()
+}
+
+// Recv receives from ch and counts the waiting as blocking
+// time of the next checkpoint of t
+func Recv[T any](t *Track, ch <-chan T) (T, bool) {
+	start := t.now()
+	v, ok := <-ch
+	t.block(t.now().Sub(start))
+	return v, ok
+}
+
+// Send sends v into ch and counts the waiting as blocking
+// time of the next checkpoint of t
+func Send[T any](t *Track, ch chan<- T, v T) {
+	start := t.now()
+	ch <- v
+	t.block(t.now().Sub(start))
+}
+
+func (t *Track) block(d time.Duration) {
+	t.mu.Lock()
+	t.blocked += d
+	t.mu.Unlock()
+}
+
+// Busy returns the duration of the step without the known blocking
+// time, i.e. the time the step was computing rather than waiting
+func (iter Meta) Busy() time.Duration {
+	if iter.Blocked >= iter.Dur {
+		return 0
+	}
+	return iter.Dur - iter.Blocked
+}
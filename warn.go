@@ -0,0 +1,57 @@
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Warnings configures the WARN lines a Loggable track prints as soon as
+// a checkpoint takes too long, instead of just marking it in the report
+type Warnings struct {
+	// Fraction of the budget or of the deadline a checkpoint may take
+	// without a warning, 1 if not positive
+	Fraction float64
+	Budgets  Budgets
+	// Deadline of the tracked work, see WithDeadline
+	Deadline time.Time
+}
+
+// WithDeadline returns w with the deadline of the context, if any
+func (w Warnings) WithDeadline(ctx context.Context) Warnings {
+	if d, ok := ctx.Deadline(); ok {
+		w.Deadline = d
+	}
+	return w
+}
+
+// SetWarnings makes a Loggable track print a WARN line for every
+// checkpoint over the fraction of its budget or of the deadline
+func (t *Track) SetWarnings(w Warnings) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if w.Fraction <= 0 {
+		w.Fraction = 1
+	}
+	t.warnings = &w
+}
+
+// warn returns the WARN line of the checkpoint or an empty string,
+// t.mu must be held
+func (t *Track) warn(m Meta) string {
+	w := t.warnings
+	if w == nil || m.Dur <= 0 {
+		return ""
+	}
+
+	if b := w.Budgets.Of(m.Name); b > 0 && m.Dur > time.Duration(w.Fraction*float64(b)) {
+		return fmt.Sprintf("WARN %sbudget:[%s]|", m.info(), b)
+	}
+	if !w.Deadline.IsZero() && t.len() > 0 {
+		window := w.Deadline.Sub(t.at(0).Start)
+		if m.Dur > time.Duration(w.Fraction*float64(window)) {
+			return fmt.Sprintf("WARN %sdeadline:[%s]|", m.info(), window)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,116 @@
+package tracker
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// wrappers holds the tracked decorators of interfaces by interface type,
+// see RegisterWrapper
+var wrappers sync.Map
+
+// RegisterWrapper registers the tracked decorator Wrap uses for the
+// interface T. Go can not implement an interface at run time, so the
+// decorators of interfaces are generated by cmd/trackerwrap which
+// registers them in init.
+func RegisterWrapper[T any](wrap func(t *Track, svc T) T) {
+	wrappers.Store(reflect.TypeFor[T](), wrap)
+}
+
+var (
+	errorType       = reflect.TypeFor[error]()
+	errNotWrappable = errors.New("only interfaces with a registered wrapper, funcs and structs of funcs can be wrapped")
+)
+
+// Wrap returns svc instrumented so that every call is recorded into t as
+// a checkpoint named after the method, with the duration of the call and
+// the error it returned, if its last result is an error:
+//   - for an interface T, the decorator registered by RegisterWrapper
+//   - for a func T, the func itself
+//   - for a struct T, its exported func fields, e.g. a client made of
+//     func fields
+//
+// Any other svc is returned as is.
+func Wrap[T any](t *Track, svc T) T {
+	typ := reflect.TypeFor[T]()
+	if wrap, ok := wrappers.Load(typ); ok {
+		return wrap.(func(*Track, T) T)(t, svc)
+	}
+
+	v := reflect.ValueOf(&svc).Elem()
+	switch typ.Kind() {
+	case reflect.Func:
+		if !v.IsNil() {
+			name := runtime.FuncForPC(v.Pointer()).Name()
+			v.Set(wrapFunc(t, name, v))
+		}
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			f := typ.Field(i)
+			if !f.IsExported() || f.Type.Kind() != reflect.Func || v.Field(i).IsNil() {
+				continue
+			}
+			v.Field(i).Set(wrapFunc(t, typ.Name()+"."+f.Name, v.Field(i)))
+		}
+	default:
+		log.Printf("err:%s; error wrapping %s", errNotWrappable.Error(), typ)
+	}
+	return svc
+}
+
+// wrapFunc returns fn recording its calls into t as the name
+func wrapFunc(t *Track, name string, fn reflect.Value) reflect.Value {
+	// fn may be the very field the wrapper is set into, take its value
+	fn = reflect.ValueOf(fn.Interface())
+	typ := fn.Type()
+	returnsErr := typ.NumOut() > 0 && typ.Out(typ.NumOut()-1) == errorType
+
+	return reflect.MakeFunc(typ, func(in []reflect.Value) []reflect.Value {
+		start := t.now()
+		var out []reflect.Value
+		if typ.IsVariadic() {
+			out = fn.CallSlice(in)
+		} else {
+			out = fn.Call(in)
+		}
+
+		end := t.now()
+		meta := Meta{Name: name, Start: end, Dur: end.Sub(start)}
+		if returnsErr {
+			meta.Err, _ = out[len(out)-1].Interface().(error)
+		}
+		if ok, _ := t.begin(); ok {
+			t.insert(meta)
+		}
+		return out
+	})
+}
+
+// maxArgLen is the number of runes an argument is cut to by Args
+const maxArgLen = 32
+
+// Args returns a short summary of the call arguments for the attributes
+// of a step, e.g. `42, "key", {1 2}`, long arguments are cut
+func Args(args ...any) string {
+	var b strings.Builder
+	for i, arg := range args {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		s := fmt.Sprint(arg)
+		if str, ok := arg.(string); ok {
+			s = strconv.Quote(str)
+		}
+		if r := []rune(s); len(r) > maxArgLen {
+			s = string(r[:maxArgLen-1]) + "…"
+		}
+		b.WriteString(s)
+	}
+	return b.String()
+}
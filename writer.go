@@ -0,0 +1,68 @@
+package tracker
+
+import "strings"
+
+// LogWriter adapts a Track to io.Writer: every line written into it
+// becomes a checkpoint named after the line prefix. Hooked into an existing
+// logger, e.g. log.SetOutput(io.MultiWriter(os.Stderr, t.Writer())),
+// it gives legacy code rough tracking without invasive changes.
+type LogWriter struct {
+	Track *Track
+	// NameFunc returns the checkpoint name for a log line,
+	// LinePrefix is used when it is nil.
+	NameFunc func(line string) string
+}
+
+// Writer returns a LogWriter which records checkpoints into t.
+func (t *Track) Writer() *LogWriter {
+	return &LogWriter{Track: t}
+}
+
+func (w *LogWriter) Write(p []byte) (int, error) {
+	if ok, err := w.Track.begin(); !ok {
+		return 0, err
+	}
+
+	name := w.NameFunc
+	if name == nil {
+		name = LinePrefix
+	}
+
+	for _, line := range strings.Split(string(p), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		w.Track.add(Meta{Name: name(line)}, "")
+	}
+	return len(p), nil
+}
+
+// LinePrefix returns the part of a log line before the first ": "
+// ("db" for "db: connection refused") or its first word if there is no such
+// separator. Date and time written by the standard logger are skipped.
+func LinePrefix(line string) string {
+	fields := strings.Fields(line)
+	for len(fields) > 1 && isTimestamp(fields[0]) {
+		fields = fields[1:]
+	}
+	if len(fields) == 0 {
+		return ""
+	}
+
+	rest := strings.Join(fields, " ")
+	if i := strings.Index(rest, ": "); i > 0 {
+		return rest[:i]
+	}
+	return strings.TrimSuffix(fields[0], ":")
+}
+
+// reports whether s looks like a date or time written by the log package
+func isTimestamp(s string) bool {
+	for _, r := range s {
+		if (r < '0' || r > '9') && !strings.ContainsRune("/:.-", r) {
+			return false
+		}
+	}
+	return true
+}